@@ -0,0 +1,263 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// plaid account types - Type is kept as the raw string plaid sends
+const (
+	AccountTypeDepository = "depository"
+	AccountTypeCredit     = "credit"
+	AccountTypeLoan       = "loan"
+	AccountTypeInvestment = "investment"
+	AccountTypeBrokerage  = "brokerage" // older items still report this
+	AccountTypeOther      = "other"
+)
+
+// CowAccount is our account storage type with the fields we use from plaid
+type CowAccount struct {
+	// The ID of the account, what CowTransaction.AccountID points at
+	AccountID string `json:"account_id" bson:"_id"`
+	// The name of the account, either assigned by the user or by the financial institution itself
+	Name string `json:"name" bson:"name"`
+	// The official name of the account as given by the financial institution
+	OfficialName string `json:"official_name" bson:"officialName"`
+	// The last 2-4 alphanumeric characters of an account's official account number
+	Mask string `json:"mask" bson:"mask"`
+	// depository, credit, loan, investment or other - see AccountType*
+	Type string `json:"type" bson:"type"`
+	// checking, savings, credit card, mortgage, 401k and so on
+	Subtype string `json:"subtype" bson:"subtype"`
+	// The total amount of funds in or owed by the account.  For credit and loan accounts a positive
+	// balance is money owed
+	CurrentBalance float64 `json:"current_balance" bson:"currentBalance"`
+	// The amount of funds available to be withdrawn, nil when the institution doesn't say
+	AvailableBalance *float64 `json:"available_balance" bson:"availableBalance"`
+	// The credit limit for credit accounts or the overdraft limit for depository ones, nil when not known
+	Limit *float64 `json:"limit" bson:"limit"`
+	// The ISO-4217 currency code of the balances. Always empty if `unofficial_currency_code` is set.
+	IsoCurrencyCode string `json:"iso_currency_code" bson:"isoCurrencyCode"`
+	// The unofficial currency code of the balances, crypto mostly
+	UnofficialCurrencyCode string    `json:"unofficial_currency_code" bson:"unofficialCurrencyCode"`
+	BalanceUpdated         time.Time `json:"balance_updated" bson:"balanceUpdated"` // gmt, when we last checked balances
+	UID                    string    `bson:"uid" json:"uid"`
+	IID                    string    `bson:"IID" json:"IID"`
+}
+
+// CurrencyCode is the iso code, or plaid's unofficial code when there is no iso one
+func (account CowAccount) CurrencyCode() string {
+	if account.IsoCurrencyCode != "" {
+		return account.IsoCurrencyCode
+	}
+	return account.UnofficialCurrencyCode
+}
+
+// IsLiability is true for accounts whose balance is money owed
+func (account CowAccount) IsLiability() bool {
+	kind := strings.ToLower(account.Type)
+	return kind == AccountTypeCredit || kind == AccountTypeLoan
+}
+
+// IsDepository is true for checking, savings and the like
+func (account CowAccount) IsDepository() bool {
+	return strings.EqualFold(account.Type, AccountTypeDepository)
+}
+
+// Available is what can be spent right now - the available balance, falling back to current
+func (account CowAccount) Available() float64 {
+	if account.AvailableBalance != nil {
+		return *account.AvailableBalance
+	}
+	return account.CurrentBalance
+}
+
+// NetWorthValue is the signed amount the account adds to net worth, liabilities count against
+func (account CowAccount) NetWorthValue() Money {
+	balance := MoneyFromFloat(account.CurrentBalance, account.CurrencyCode(), RoundHalfEven)
+	if account.IsLiability() {
+		return balance.Neg()
+	}
+	return balance
+}
+
+// Snapshot is the account's balances as of a day
+func (account CowAccount) Snapshot(on CivilDate) BalanceSnapshot {
+	return BalanceSnapshot{
+		ID:               account.AccountID + ":" + on.String(),
+		AccountID:        account.AccountID,
+		UID:              account.UID,
+		Date:             on,
+		Type:             account.Type,
+		CurrentBalance:   account.CurrentBalance,
+		AvailableBalance: account.AvailableBalance,
+		Limit:            account.Limit,
+		Currency:         account.CurrencyCode(),
+	}
+}
+
+// BalanceSnapshot is one day of an account's balance history - one per account per day, the
+// last check of the day wins
+type BalanceSnapshot struct {
+	ID               string    `json:"id" bson:"_id"` // account id:date
+	AccountID        string    `json:"account_id" bson:"accountId"`
+	UID              string    `json:"uid" bson:"uid"`
+	Date             CivilDate `json:"date" bson:"date"`
+	Type             string    `json:"type" bson:"type"` // so history can be summed without the accounts
+	CurrentBalance   float64   `json:"current_balance" bson:"currentBalance"`
+	AvailableBalance *float64  `json:"available_balance" bson:"availableBalance"`
+	Limit            *float64  `json:"limit" bson:"limit"`
+	Currency         string    `json:"currency" bson:"currency"`
+}
+
+// account is enough of the account back to reuse its methods
+func (snapshot BalanceSnapshot) account() CowAccount {
+	return CowAccount{
+		AccountID:        snapshot.AccountID,
+		UID:              snapshot.UID,
+		Type:             snapshot.Type,
+		CurrentBalance:   snapshot.CurrentBalance,
+		AvailableBalance: snapshot.AvailableBalance,
+		Limit:            snapshot.Limit,
+		IsoCurrencyCode:  snapshot.Currency,
+	}
+}
+
+// BalanceHistory is snapshots sorted by date then account
+type BalanceHistory []BalanceSnapshot
+
+// Record adds a snapshot, replacing any for the same account and day
+func (history BalanceHistory) Record(snapshot BalanceSnapshot) BalanceHistory {
+	out := make(BalanceHistory, 0, len(history)+1)
+	for _, known := range history {
+		if known.AccountID != snapshot.AccountID || known.Date != snapshot.Date {
+			out = append(out, known)
+		}
+	}
+	out = append(out, snapshot)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Date != out[j].Date {
+			return out[i].Date.Before(out[j].Date)
+		}
+		return out[i].AccountID < out[j].AccountID
+	})
+	return out
+}
+
+// On is each account's latest snapshot on or before a day
+func (history BalanceHistory) On(day CivilDate) []BalanceSnapshot {
+	latest := map[string]BalanceSnapshot{}
+	var order []string
+	for _, snapshot := range history {
+		if snapshot.Date.After(day) {
+			continue
+		}
+		if _, seen := latest[snapshot.AccountID]; !seen {
+			order = append(order, snapshot.AccountID)
+		}
+		if known, seen := latest[snapshot.AccountID]; !seen || !snapshot.Date.Before(known.Date) {
+			latest[snapshot.AccountID] = snapshot
+		}
+	}
+	sort.Strings(order)
+	out := make([]BalanceSnapshot, 0, len(order))
+	for _, id := range order {
+		out = append(out, latest[id])
+	}
+	return out
+}
+
+// NetWorthPoint is a user's net worth on one day
+type NetWorthPoint struct {
+	UID         string    `json:"uid" bson:"uid"`
+	Date        CivilDate `json:"date" bson:"date"`
+	Assets      Money     `json:"assets" bson:"assets"`
+	Liabilities Money     `json:"liabilities" bson:"liabilities"` // positive, what is owed
+	NetWorth    Money     `json:"net_worth" bson:"netWorth"`
+	Currency    string    `json:"currency" bson:"currency"`
+}
+
+// Ship is the ship for this net worth, kept steady against the current one
+func (point NetWorthPoint) Ship(current ShipTypes) (ShipTypes, ShipProgress) {
+	ship := ShipWithHysteresis(current, point.NetWorth.Float64())
+	return ship, ProgressForNetWorth(ship, point.NetWorth.Float64())
+}
+
+// NetWorth adds up accounts in the home currency, each converted on the day of its balance.
+// rates can be nil when every account is already in home
+func NetWorth(uid string, accounts []CowAccount, home string, on CivilDate, rates ExchangeRates) (NetWorthPoint, error) {
+	home = strings.ToUpper(home)
+	point := NetWorthPoint{UID: uid, Date: on, Assets: NewMoney(0, home), Liabilities: NewMoney(0, home), Currency: home}
+	for _, account := range accounts {
+		if uid != "" && account.UID != "" && account.UID != uid {
+			continue
+		}
+		value := account.NetWorthValue()
+		when := on.In(time.UTC)
+		if !account.BalanceUpdated.IsZero() {
+			when = account.BalanceUpdated
+		}
+		converted, err := Convert(value, home, when, rates)
+		if err != nil {
+			return NetWorthPoint{}, fmt.Errorf("account %s: %w", account.AccountID, err)
+		}
+		if converted.IsNegative() {
+			point.Liabilities, _ = point.Liabilities.Add(converted.Neg())
+		} else {
+			point.Assets, _ = point.Assets.Add(converted)
+		}
+	}
+	point.NetWorth, _ = point.Assets.Sub(point.Liabilities)
+	return point, nil
+}
+
+// NetWorthOn is net worth from the history, each account at its latest balance on or before the day
+func (history BalanceHistory) NetWorthOn(uid string, day CivilDate, home string, rates ExchangeRates) (NetWorthPoint, error) {
+	var accounts []CowAccount
+	for _, snapshot := range history.On(day) {
+		account := snapshot.account()
+		account.BalanceUpdated = snapshot.Date.In(time.UTC)
+		accounts = append(accounts, account)
+	}
+	return NetWorth(uid, accounts, home, day, rates)
+}
+
+// NetWorthSeries is net worth for every day in [from, to) that has a snapshot, for charts
+func (history BalanceHistory) NetWorthSeries(uid string, from CivilDate, to CivilDate, home string, rates ExchangeRates) ([]NetWorthPoint, error) {
+	var out []NetWorthPoint
+	last := CivilDate{}
+	for _, snapshot := range history {
+		day := snapshot.Date
+		if day.Before(from) || !day.Before(to) || day == last || (uid != "" && snapshot.UID != uid) {
+			continue
+		}
+		last = day
+		point, err := history.NetWorthOn(uid, day, home, rates)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, point)
+	}
+	return out, nil
+}
+
+// PushAccountsQ asks the front end to take a fresh copy of a user's accounts
+func PushAccountsQ(uid string, accounts []CowAccount) Q {
+	extra, _ := json.Marshal(struct {
+		Accounts []CowAccount `json:"accounts"`
+	}{accounts})
+	return Q{
+		Added: time.Now().UTC(),
+		UID:   uid,
+		Event: EventPushAccounts,
+		Extra: string(extra),
+	}
+}
+
+// CheckBalancesQ asks the workers to refresh a user's balances
+func CheckBalancesQ(uid string) Q {
+	return Q{Added: time.Now().UTC(), UID: uid, Event: EventCheckBalances}
+}
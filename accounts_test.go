@@ -0,0 +1,97 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func float(value float64) *float64 {
+	return &value
+}
+
+func TestCowAccountBalances(t *testing.T) {
+	checking := CowAccount{Type: "Depository", CurrentBalance: 120, AvailableBalance: float(95.5), IsoCurrencyCode: "USD"}
+	if !checking.IsDepository() || checking.IsLiability() || checking.Available() != 95.5 {
+		t.Errorf("checking %+v", checking)
+	}
+	card := CowAccount{Type: AccountTypeCredit, CurrentBalance: 300.1, UnofficialCurrencyCode: "USD"}
+	if !card.IsLiability() || card.Available() != 300.1 || card.CurrencyCode() != "USD" {
+		t.Errorf("card %+v", card)
+	}
+	if value := card.NetWorthValue(); value != NewMoney(-30010, "USD") {
+		t.Errorf("card value %v", value)
+	}
+}
+
+func TestNetWorth(t *testing.T) {
+	rates := testExchangeRates(t)
+	on := civilDay(t, "2023-01-10")
+	accounts := []CowAccount{
+		{AccountID: "checking", UID: "u1", Type: AccountTypeDepository, CurrentBalance: 1000.1, IsoCurrencyCode: "USD"},
+		{AccountID: "euro", UID: "u1", Type: AccountTypeDepository, CurrentBalance: 100, IsoCurrencyCode: "EUR"},
+		{AccountID: "card", UID: "u1", Type: AccountTypeCredit, CurrentBalance: 250.2, IsoCurrencyCode: "USD"},
+		{AccountID: "loan", UID: "u1", Type: AccountTypeLoan, CurrentBalance: 500},
+		{AccountID: "someone else", UID: "u2", Type: AccountTypeDepository, CurrentBalance: 1e6, IsoCurrencyCode: "USD"},
+	}
+	point, err := NetWorth("u1", accounts, "usd", on, rates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if point.Assets != NewMoney(111010, "USD") || point.Liabilities != NewMoney(75020, "USD") || point.NetWorth != NewMoney(35990, "USD") {
+		t.Errorf("point %+v", point)
+	}
+	if _, err := NetWorth("u1", accounts, "USD", on, nil); !errors.Is(err, ErrNoExchangeRate) || !strings.Contains(err.Error(), "euro") {
+		t.Errorf("no rates: %v", err)
+	}
+	if _, err := NetWorth("u1", accounts[:1], "USD", on, nil); err != nil {
+		t.Errorf("home only without rates: %v", err)
+	}
+	if ship, progress := point.Ship(ScoutShip); ship != ScoutShip || progress.ToGo != 4640.1 {
+		t.Errorf("ship %s %+v", ship, progress)
+	}
+}
+
+func TestBalanceHistory(t *testing.T) {
+	checking := CowAccount{AccountID: "checking", UID: "u1", Type: AccountTypeDepository, CurrentBalance: 100, IsoCurrencyCode: "USD"}
+	card := CowAccount{AccountID: "card", UID: "u1", Type: AccountTypeCredit, CurrentBalance: 40, IsoCurrencyCode: "USD"}
+	var history BalanceHistory
+	history = history.Record(checking.Snapshot(civilDay(t, "2023-03-02")))
+	history = history.Record(card.Snapshot(civilDay(t, "2023-03-01")))
+	checking.CurrentBalance = 150
+	history = history.Record(checking.Snapshot(civilDay(t, "2023-03-02"))) // later check the same day wins
+	checking.CurrentBalance = 90
+	history = history.Record(checking.Snapshot(civilDay(t, "2023-03-04")))
+	if len(history) != 3 || history[0].AccountID != "card" || history[1].ID != "checking:2023-03-02" || history[1].CurrentBalance != 150 {
+		t.Fatalf("history %+v", history)
+	}
+	on := history.On(civilDay(t, "2023-03-03"))
+	if len(on) != 2 || on[0].AccountID != "card" || on[1].CurrentBalance != 150 {
+		t.Errorf("on %+v", on)
+	}
+	point, err := history.NetWorthOn("u1", civilDay(t, "2023-03-03"), "USD", nil)
+	if err != nil || point.NetWorth != NewMoney(11000, "USD") {
+		t.Errorf("net worth on %+v %v", point, err)
+	}
+	series, err := history.NetWorthSeries("u1", civilDay(t, "2023-03-01"), civilDay(t, "2023-03-04"), "USD", nil)
+	if err != nil || len(series) != 2 {
+		t.Fatalf("series %+v %v", series, err)
+	}
+	if series[0].NetWorth != NewMoney(-4000, "USD") || series[1].Date.String() != "2023-03-02" {
+		t.Errorf("series %+v", series)
+	}
+}
+
+func TestAccountQueueEntries(t *testing.T) {
+	q := PushAccountsQ("u1", []CowAccount{{AccountID: "a", Name: "Checking"}})
+	var extra struct {
+		Accounts []CowAccount `json:"accounts"`
+	}
+	if err := json.Unmarshal([]byte(q.Extra), &extra); err != nil || q.Event != EventPushAccounts || len(extra.Accounts) != 1 {
+		t.Errorf("push %+v %v", q, err)
+	}
+	if q := CheckBalancesQ("u1"); q.Event != EventCheckBalances || q.UID != "u1" {
+		t.Errorf("check %+v", q)
+	}
+}
@@ -0,0 +1,356 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+)
+
+// AnomalyKind is which check an unusual transaction tripped
+type AnomalyKind int
+
+const (
+	AnomalyCategorySpike   AnomalyKind = iota // far above what the user usually spends in the category
+	AnomalyNewMerchant                        // a big first purchase somewhere new
+	AnomalyUnusualHour                        // in store at an hour the user is never out shopping
+	AnomalyUnusualLocation                    // in store somewhere the user has never been
+	AnomalyCategoryMonth                      // the month's category total is far above normal
+)
+
+var anomalyKindNames = enumNames[AnomalyKind]{kind: "anomaly", names: map[AnomalyKind]string{
+	AnomalyCategorySpike:   "category_spike",
+	AnomalyNewMerchant:     "new_merchant",
+	AnomalyUnusualHour:     "unusual_hour",
+	AnomalyUnusualLocation: "unusual_location",
+	AnomalyCategoryMonth:   "category_month",
+}}
+
+func (k AnomalyKind) String() string {
+	return anomalyKindNames.name(k)
+}
+
+// MarshalJSON writes the check's name, which is also the tip.unusual.* message it's shown with
+func (k AnomalyKind) MarshalJSON() ([]byte, error) {
+	return anomalyKindNames.marshalJSON(k)
+}
+
+// UnmarshalJSON reads the check names back
+func (k *AnomalyKind) UnmarshalJSON(raw []byte) error {
+	return anomalyKindNames.unmarshalJSON(raw, k)
+}
+
+// Anomaly is one scored bit of unusual spending.  Score is how far past the threshold it is -
+// 1 is just over, anything below 1 isn't reported
+type Anomaly struct {
+	Kind          AnomalyKind `json:"kind" bson:"kind"`
+	UID           string      `json:"uid" bson:"uid"`
+	TransactionID string      `json:"transaction_id" bson:"transactionId"` // empty for monthly totals
+	FlatType      string      `json:"flat_type" bson:"flatType"`
+	Merchant      string      `json:"merchant" bson:"merchant"`
+	Date          CivilDate   `json:"date" bson:"date"`
+	Amount        float64     `json:"amount" bson:"amount"`
+	Baseline      float64     `json:"baseline" bson:"baseline"` // the usual amount it was compared with
+	Score         float64     `json:"score" bson:"score"`
+}
+
+// AnomalySensitivity is how jumpy the detector is
+type AnomalySensitivity struct {
+	ZScore             float64 // standard deviations above the mean before a spike counts
+	NewMerchantFactor  float64 // a first purchase this many times the median charge is unusual
+	MonthFactor        float64 // and a month this many times the usual category total
+	MinAmount          float64 // nothing smaller is ever unusual
+	CheckHours         bool
+	CheckLocations     bool
+	MinHistory         int     // baseline charges needed before a check says anything
+	LocationPrecision  int     // geohash characters compared, 4 is roughly 40km
+	UnusualHourPercent float64 // an hour with less than this share of in store purchases around it is odd
+	BaselineDays       int     // only history this many days before the oldest recent transaction counts, 0 for all
+}
+
+// the better the subscription the more we look and the earlier we speak up
+var anomalySensitivities = map[SubscriptionLevel]AnomalySensitivity{
+	Trial:           {ZScore: 3.5, NewMerchantFactor: 8, MonthFactor: 2.5, MinAmount: 50, MinHistory: 20, LocationPrecision: 4, UnusualHourPercent: 1, BaselineDays: 365},
+	Skim:            {ZScore: 3, NewMerchantFactor: 6, MonthFactor: 2, MinAmount: 40, MinHistory: 15, LocationPrecision: 4, UnusualHourPercent: 1, BaselineDays: 365},
+	LowFat:          {ZScore: 3, NewMerchantFactor: 5, MonthFactor: 2, MinAmount: 30, CheckHours: true, MinHistory: 15, LocationPrecision: 4, UnusualHourPercent: 1, BaselineDays: 365},
+	Whole:           {ZScore: 2.5, NewMerchantFactor: 4, MonthFactor: 1.75, MinAmount: 25, CheckHours: true, CheckLocations: true, MinHistory: 10, LocationPrecision: 4, UnusualHourPercent: 2, BaselineDays: 365},
+	Hyperpasturized: {ZScore: 2, NewMerchantFactor: 3, MonthFactor: 1.5, MinAmount: 20, CheckHours: true, CheckLocations: true, MinHistory: 10, LocationPrecision: 4, UnusualHourPercent: 2, BaselineDays: 365},
+}
+
+// AnomalySensitivityFor is the sensitivity for a subscription level, Trial's for levels we don't know
+func AnomalySensitivityFor(level SubscriptionLevel) AnomalySensitivity {
+	if found, ok := anomalySensitivities[level]; ok {
+		return found
+	}
+	return anomalySensitivities[Trial]
+}
+
+// AnomalyDetector compares new transactions with a user's history.  Build it with
+// NewAnomalyDetector - an empty Sensitivity has no thresholds and flags nearly everything
+type AnomalyDetector struct {
+	Sensitivity AnomalySensitivity
+	Location    *time.Location // user's zone for dates and hours (UTC)
+}
+
+// NewAnomalyDetector is a detector tuned for a subscription level
+func NewAnomalyDetector(level SubscriptionLevel) AnomalyDetector {
+	return AnomalyDetector{Sensitivity: AnomalySensitivityFor(level)}
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	squares := 0.0
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
+
+func isInStore(transaction CowTransaction) bool {
+	return transaction.PaymentChannel == "in store" || transaction.IsPhysicalLocation
+}
+
+func geoCell(geoHash string, precision int) string {
+	if len(geoHash) < precision {
+		return ""
+	}
+	return geoHash[:precision]
+}
+
+// baseline is the part of history inside the BaselineDays before the oldest recent transaction,
+// so habits from years ago don't hide what's unusual now
+func (d AnomalyDetector) baseline(history []CowTransaction, recent []CowTransaction) []CowTransaction {
+	if d.Sensitivity.BaselineDays <= 0 {
+		return history
+	}
+	var oldest CivilDate
+	for _, transaction := range recent {
+		if day := transaction.EffectiveDate(d.Location); !day.IsZero() && (oldest.IsZero() || day.Before(oldest)) {
+			oldest = day
+		}
+	}
+	if oldest.IsZero() {
+		return history
+	}
+	from := oldest.AddDays(-d.Sensitivity.BaselineDays)
+	var out []CowTransaction
+	for _, transaction := range history {
+		if day := transaction.EffectiveDate(d.Location); !day.IsZero() && !day.Before(from) {
+			out = append(out, transaction)
+		}
+	}
+	return out
+}
+
+// flatTypes classifies each transaction once, Classify isn't cheap
+func flatTypes(transactions []CowTransaction) []string {
+	out := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		if transaction.Amount > 0 {
+			out[i] = flatType(transaction)
+		}
+	}
+	return out
+}
+
+// Detect scores the charges in recent against history, the user's older transactions, most
+// unusual first.  Both should belong to one user
+func (d AnomalyDetector) Detect(history []CowTransaction, recent []CowTransaction) []Anomaly {
+	if d.Location == nil {
+		d.Location = time.UTC
+	}
+	s := d.Sensitivity
+	history = d.baseline(history, recent)
+	historyTypes, recentTypes := flatTypes(history), flatTypes(recent)
+	byType := map[string][]float64{}
+	merchants := map[string]bool{}
+	cells := map[string]bool{}
+	hours := make([]int, 24)
+	var amounts []float64
+	exactTimes := 0
+	for i, transaction := range history {
+		if transaction.Amount <= 0 {
+			continue
+		}
+		amounts = append(amounts, transaction.Amount)
+		byType[historyTypes[i]] = append(byType[historyTypes[i]], transaction.Amount)
+		merchants[merchantKey(transaction)] = true
+		if !isInStore(transaction) {
+			continue
+		}
+		if cell := geoCell(transaction.GeoHash, s.LocationPrecision); cell != "" {
+			cells[cell] = true
+		}
+		if when, exact := transaction.EffectiveTime(d.Location); exact {
+			hours[when.Hour()]++
+			exactTimes++
+		}
+	}
+	typicalCharge := median(amounts)
+	var out []Anomaly
+	for i, transaction := range recent {
+		if transaction.Amount <= 0 || transaction.Amount < s.MinAmount {
+			continue
+		}
+		category := recentTypes[i]
+		found := func(kind AnomalyKind, baseline float64, score float64) {
+			out = append(out, Anomaly{
+				Kind:          kind,
+				UID:           transaction.UID,
+				TransactionID: transaction.TransactionID,
+				FlatType:      category,
+				Merchant:      NormalizeMerchant(transaction.Name).DisplayName,
+				Date:          transaction.EffectiveDate(d.Location),
+				Amount:        transaction.Amount,
+				Baseline:      math.Round(baseline*100) / 100,
+				Score:         math.Round(score*100) / 100,
+			})
+			if transaction.MerchantName != "" {
+				out[len(out)-1].Merchant = transaction.MerchantName
+			}
+		}
+		if seen := byType[category]; len(seen) >= s.MinHistory {
+			mean, deviation := meanStdDev(seen)
+			if limit := mean + s.ZScore*deviation; deviation > 0 && transaction.Amount > limit {
+				found(AnomalyCategorySpike, mean, transaction.Amount/limit)
+			}
+		}
+		if len(amounts) >= s.MinHistory && !merchants[merchantKey(transaction)] {
+			if limit := typicalCharge * s.NewMerchantFactor; transaction.Amount > limit {
+				found(AnomalyNewMerchant, typicalCharge, transaction.Amount/limit)
+			}
+		}
+		if !isInStore(transaction) {
+			continue
+		}
+		if cell := geoCell(transaction.GeoHash, s.LocationPrecision); s.CheckLocations && cell != "" &&
+			len(cells) > 0 && len(amounts) >= s.MinHistory && !cells[cell] {
+			found(AnomalyUnusualLocation, 0, 1)
+		}
+		if when, exact := transaction.EffectiveTime(d.Location); s.CheckHours && exact && exactTimes >= s.MinHistory {
+			// the hour either side counts too so 9:55 isn't odd for someone who shops at 10
+			nearby := hours[(when.Hour()+23)%24] + hours[when.Hour()] + hours[(when.Hour()+1)%24]
+			if share := float64(nearby) * 100 / float64(exactTimes); share < s.UnusualHourPercent {
+				found(AnomalyUnusualHour, 0, 1+(s.UnusualHourPercent-share)/s.UnusualHourPercent)
+			}
+		}
+	}
+	var latest CivilDate
+	for _, transaction := range recent {
+		if day := transaction.EffectiveDate(d.Location); day.After(latest) {
+			latest = day
+		}
+	}
+	all := append(append([]CowTransaction(nil), history...), recent...)
+	out = append(out, d.monthly(all, append(historyTypes, recentTypes...), latest)...)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Score > out[j].Score
+	})
+	return out
+}
+
+// monthly compares the category totals of the month latest is in with the months before it.
+// transactions is the history followed by the recent ones and types their flatTypes
+func (d AnomalyDetector) monthly(transactions []CowTransaction, types []string, latest CivilDate) []Anomaly {
+	s := d.Sensitivity
+	if latest.IsZero() {
+		return nil
+	}
+	bucketer := Bucketer{Location: d.Location}
+	current, _ := bucketer.Bucket(latest, BucketMonth)
+	totals := map[string]map[CivilDate]float64{}
+	months := map[CivilDate]bool{}
+	uid := ""
+	seen := map[string]bool{}
+	for i, transaction := range transactions {
+		if transaction.Amount <= 0 || seen[transaction.TransactionID] && transaction.TransactionID != "" {
+			continue
+		}
+		seen[transaction.TransactionID] = true
+		day := transaction.EffectiveDate(d.Location)
+		if day.IsZero() || day.After(latest) {
+			continue
+		}
+		uid = transaction.UID
+		start, _ := bucketer.Bucket(day, BucketMonth)
+		category := types[i]
+		if totals[category] == nil {
+			totals[category] = map[CivilDate]float64{}
+		}
+		totals[category][start] += transaction.Amount
+		months[start] = true
+	}
+	if len(months) < 4 {
+		return nil // three full months before this one at least
+	}
+	var categories []string
+	for category := range totals {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	var out []Anomaly
+	for _, category := range categories {
+		var previous []float64
+		for month := range months {
+			if month != current {
+				previous = append(previous, totals[category][month])
+			}
+		}
+		mean, _ := meanStdDev(previous)
+		total := totals[category][current]
+		if limit := mean * s.MonthFactor; mean > 0 && total >= s.MinAmount && total > limit {
+			out = append(out, Anomaly{
+				Kind:     AnomalyCategoryMonth,
+				UID:      uid,
+				FlatType: category,
+				Date:     current,
+				Amount:   math.Round(total*100) / 100,
+				Baseline: math.Round(mean*100) / 100,
+				Score:    math.Round(total/limit*100) / 100,
+			})
+		}
+	}
+	return out
+}
+
+// Tip turns the anomaly into a CowTipsUnusual tip with an English Description
+func (a Anomaly) Tip() CowTips {
+	return DefaultCatalog().LocalizeTip(CowTips{
+		Type:       CowTipsUnusual,
+		MessageKey: "tip.unusual." + a.Kind.String(),
+		MessageArgs: map[string]string{
+			"category": a.FlatType,
+			"merchant": a.Merchant,
+			"amount":   formatAmount(a.Amount),
+			"baseline": formatAmount(a.Baseline),
+			"date":     a.Date.String(),
+		},
+		Rule: "unusual_spending",
+		Key:  "unusual_spending:" + a.Kind.String() + ":" + a.TransactionID + ":" + a.FlatType + ":" + a.Date.String(),
+	}, En)
+}
+
+// AlertQ wraps the anomaly into an EventAlert queue entry
+func (a Anomaly) AlertQ() Q {
+	extra, _ := json.Marshal(struct {
+		Anomaly Anomaly `json:"anomaly"`
+	}{a})
+	return Q{
+		Added: time.Now().UTC(),
+		UID:   a.UID,
+		Event: EventAlert,
+		Extra: string(extra),
+	}
+}
+
+// DetectAnomalies runs a detector tuned for the user's subscription level
+func DetectAnomalies(level SubscriptionLevel, history []CowTransaction, recent []CowTransaction) []Anomaly {
+	return NewAnomalyDetector(level).Detect(history, recent)
+}
@@ -0,0 +1,136 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// dinners is n restaurant charges of 20 and 30 alternating, one every three days from start
+func dinners(t *testing.T, start string, n int) []CowTransaction {
+	t.Helper()
+	first := civilDay(t, start)
+	var out []CowTransaction
+	for i := 0; i < n; i++ {
+		amount := 20.0
+		if i%2 == 1 {
+			amount = 30
+		}
+		day := first.AddDays(3 * i)
+		out = append(out, CowTransaction{
+			TransactionID:      "dinner-" + day.String(),
+			UID:                "u1",
+			Name:               "Chez Panisse",
+			CategoryID:         "13005000",
+			Amount:             amount,
+			Date:               day.String(),
+			AuthorizedDatetime: day.In(time.UTC).Add(19 * time.Hour),
+			PaymentChannel:     "in store",
+			GeoHash:            "9q9p1",
+		})
+	}
+	return out
+}
+
+func kinds(anomalies []Anomaly) map[AnomalyKind]Anomaly {
+	out := map[AnomalyKind]Anomaly{}
+	for _, anomaly := range anomalies {
+		out[anomaly.Kind] = anomaly
+	}
+	return out
+}
+
+func TestCategorySpikeAndNewMerchant(t *testing.T) {
+	history := dinners(t, "2023-01-01", 30)
+	spike := history[0]
+	spike.TransactionID, spike.Date, spike.Amount = "spike", "2023-04-15", 400
+	stranger := spike
+	stranger.TransactionID, stranger.Name, stranger.CategoryID, stranger.Amount = "new", "Ritz Jewelers", "19000000", 300
+	found := kinds(DetectAnomalies(Trial, history, []CowTransaction{spike, stranger}))
+	if a, ok := found[AnomalyCategorySpike]; !ok || a.TransactionID != "spike" || a.Baseline != 25 || a.Score < 9 {
+		t.Errorf("spike %+v", a)
+	}
+	if a, ok := found[AnomalyNewMerchant]; !ok || a.TransactionID != "new" || a.Merchant != "Ritz Jewelers" {
+		t.Errorf("new merchant %+v", a)
+	}
+	small := spike
+	small.Amount = 45 // over the spike limit but under Trial's minimum
+	if anomalies := DetectAnomalies(Trial, history, []CowTransaction{small}); len(anomalies) != 0 {
+		t.Errorf("small charge %+v", anomalies)
+	}
+}
+
+func TestAnomalyBaselineWindow(t *testing.T) {
+	// a couple of years back the user ate out somewhere pricey all the time
+	var history []CowTransaction
+	for _, old := range dinners(t, "2021-01-01", 40) {
+		old.Amount *= 15
+		history = append(history, old)
+	}
+	history = append(history, dinners(t, "2023-01-01", 30)...)
+	spike := history[len(history)-1]
+	spike.TransactionID, spike.Date, spike.Amount = "spike", "2023-04-15", 400
+	detector := NewAnomalyDetector(Trial)
+	if _, ok := kinds(detector.Detect(history, []CowTransaction{spike}))[AnomalyCategorySpike]; !ok {
+		t.Error("old habits hid the spike")
+	}
+	detector.Sensitivity.BaselineDays = 0
+	if _, ok := kinds(detector.Detect(history, []CowTransaction{spike}))[AnomalyCategorySpike]; ok {
+		t.Error("spike found against the whole history")
+	}
+}
+
+func TestUnusualHourAndLocation(t *testing.T) {
+	history := dinners(t, "2023-01-01", 30)
+	odd := history[0]
+	odd.TransactionID, odd.Date, odd.Amount = "odd", "2023-04-15", 30
+	odd.AuthorizedDatetime = time.Date(2023, 4, 15, 3, 30, 0, 0, time.UTC)
+	odd.GeoHash = "dr5ru"
+	found := kinds(DetectAnomalies(Whole, history, []CowTransaction{odd}))
+	if _, ok := found[AnomalyUnusualHour]; !ok {
+		t.Errorf("no unusual hour in %+v", found)
+	}
+	if _, ok := found[AnomalyUnusualLocation]; !ok {
+		t.Errorf("no unusual location in %+v", found)
+	}
+	if anomalies := DetectAnomalies(Trial, history, []CowTransaction{odd}); len(anomalies) != 0 {
+		t.Errorf("trial checks hours %+v", anomalies)
+	}
+}
+
+func TestCategoryMonth(t *testing.T) {
+	history := dinners(t, "2023-01-01", 30) // january to mid march
+	var recent []CowTransaction
+	for _, day := range []string{"2023-04-02", "2023-04-09", "2023-04-16", "2023-04-23"} {
+		dinner := history[1]
+		dinner.TransactionID, dinner.Date, dinner.AuthorizedDatetime = "april-"+day, day, time.Time{}
+		dinner.Amount = 200
+		recent = append(recent, dinner)
+	}
+	found := kinds(DetectAnomalies(Trial, history, recent))
+	month, ok := found[AnomalyCategoryMonth]
+	if !ok || month.Date.String() != "2023-04-01" || month.Amount != 800 || month.TransactionID != "" {
+		t.Errorf("month %+v", month)
+	}
+}
+
+func TestAnomalyJSONAndTip(t *testing.T) {
+	anomaly := Anomaly{Kind: AnomalyUnusualLocation, UID: "u1", Merchant: "Acme", Amount: 12.5}
+	raw, err := json.Marshal(anomaly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Anomaly
+	if err := json.Unmarshal(raw, &back); err != nil || back.Kind != AnomalyUnusualLocation {
+		t.Errorf("%s: %v %v", raw, back.Kind, err)
+	}
+	for kind := AnomalyCategorySpike; kind <= AnomalyCategoryMonth; kind++ {
+		anomaly.Kind = kind
+		if tip := anomaly.Tip(); tip.Description == "" || tip.Description == tip.MessageKey {
+			t.Errorf("%s has no message", kind)
+		}
+	}
+	if q := anomaly.AlertQ(); q.Event != EventAlert || q.UID != "u1" {
+		t.Errorf("alert %+v", q)
+	}
+}
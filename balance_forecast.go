@@ -0,0 +1,255 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OverdraftCategoryID is "bank fees=>overdraft", the fee the low balance alerts are trying to avoid
+const OverdraftCategoryID = "10001000"
+
+// TypicalOverdraftFee is used for the fee estimate when the user has never been charged one
+const TypicalOverdraftFee = 35.0
+
+// LowBalanceKind is how bad a projected balance gets
+type LowBalanceKind int
+
+const (
+	LowBalanceThreshold LowBalanceKind = iota // under the user's threshold but not overdrawn
+	LowBalanceOverdraft                       // under zero
+)
+
+var lowBalanceKindNames = enumNames[LowBalanceKind]{kind: "low balance", names: map[LowBalanceKind]string{
+	LowBalanceThreshold: "low_balance",
+	LowBalanceOverdraft: "overdraft",
+}}
+
+func (k LowBalanceKind) String() string {
+	return lowBalanceKindNames.name(k)
+}
+
+// MarshalJSON writes "low_balance" or "overdraft" so the app can pick the warning's wording
+func (k LowBalanceKind) MarshalJSON() ([]byte, error) {
+	return lowBalanceKindNames.marshalJSON(k)
+}
+
+// UnmarshalJSON reads the names back
+func (k *LowBalanceKind) UnmarshalJSON(raw []byte) error {
+	return lowBalanceKindNames.unmarshalJSON(raw, k)
+}
+
+// ProjectedBalanceDay is one simulated day of an account
+type ProjectedBalanceDay struct {
+	Date    CivilDate        `json:"date" bson:"date"`
+	Charges []UpcomingCharge `json:"charges" bson:"charges"`
+	Income  []UpcomingCharge `json:"income" bson:"income"`   // Amount is negative, money coming in
+	Balance float64          `json:"balance" bson:"balance"` // end of the day
+}
+
+// LowBalanceAlert is the warning raised from a projection
+type LowBalanceAlert struct {
+	Kind        LowBalanceKind   `json:"kind" bson:"kind"`
+	UID         string           `json:"uid" bson:"uid"`
+	AccountID   string           `json:"account_id" bson:"accountId"`
+	AccountName string           `json:"account_name" bson:"accountName"`
+	Mask        string           `json:"mask" bson:"mask"`
+	Date        CivilDate        `json:"date" bson:"date"`       // first day under zero for overdrafts, Threshold otherwise
+	Balance     float64          `json:"balance" bson:"balance"` // lowest before the next income
+	LowestOn    CivilDate        `json:"lowest_on" bson:"lowestOn"`
+	Threshold   float64          `json:"threshold" bson:"threshold"`
+	NextIncome  CivilDate        `json:"next_income" bson:"nextIncome"`   // zero when none is expected in the window
+	Charges     []UpcomingCharge `json:"charges" bson:"charges"`          // what takes it under on Date
+	FeeEstimate float64          `json:"fee_estimate" bson:"feeEstimate"` // overdraft only
+	PastFees    int              `json:"past_fees" bson:"pastFees"`       // overdraft fees in the history given
+}
+
+// BalanceProjection is a depository account simulated forward from its available balance
+type BalanceProjection struct {
+	UID        string                `json:"uid" bson:"uid"`
+	AccountID  string                `json:"account_id" bson:"accountId"`
+	From       CivilDate             `json:"from" bson:"from"`
+	Start      float64               `json:"start" bson:"start"`
+	Days       []ProjectedBalanceDay `json:"days" bson:"days"`
+	Lowest     float64               `json:"lowest" bson:"lowest"`
+	LowestOn   CivilDate             `json:"lowest_on" bson:"lowestOn"`
+	NextIncome CivilDate             `json:"next_income" bson:"nextIncome"`
+	Alert      *LowBalanceAlert      `json:"alert" bson:"alert"` // nil when all is well
+}
+
+// BalanceProjector simulates an account's balance from its recurring charges and income.  An
+// empty one looks 30 days ahead in UTC and only warns about overdrafts
+type BalanceProjector struct {
+	Days       int              // days simulated (30)
+	Threshold  float64          // warn below this, 0 only warns about overdrafts
+	Forecaster ChargeForecaster // dates and amounts of the charges
+	Location   *time.Location   // user's zone for dates (UTC)
+}
+
+func (p BalanceProjector) withDefaults() BalanceProjector {
+	if p.Days <= 0 {
+		p.Days = 30
+	}
+	if p.Location == nil {
+		p.Location = time.UTC
+	}
+	if p.Forecaster.Location == nil {
+		p.Forecaster.Location = p.Location
+	}
+	return p
+}
+
+// subscriptionAccounts is the account each user's merchant was last seen on
+func subscriptionAccounts(history []CowTransaction, loc *time.Location) map[string]string {
+	accounts := map[string]string{}
+	latest := map[string]CivilDate{}
+	for _, transaction := range history {
+		key := subscriptionKey(transaction.UID, merchantKey(transaction))
+		day := transaction.EffectiveDate(loc)
+		if transaction.AccountID == "" || day.Before(latest[key]) {
+			continue
+		}
+		accounts[key] = transaction.AccountID
+		latest[key] = day
+	}
+	return accounts
+}
+
+// overdraftFees is how many overdraft fees history has and what they usually cost
+func overdraftFees(history []CowTransaction, account CowAccount) (int, float64) {
+	var fees []float64
+	for _, transaction := range history {
+		if transaction.Amount <= 0 || (transaction.AccountID != "" && transaction.AccountID != account.AccountID) {
+			continue
+		}
+		if transactionCategoryID(transaction) == OverdraftCategoryID {
+			fees = append(fees, transaction.Amount)
+		}
+	}
+	if len(fees) == 0 {
+		return 0, TypicalOverdraftFee
+	}
+	return len(fees), MoneyFromFloat(median(fees), account.CurrencyCode(), RoundHalfEven).Float64()
+}
+
+// Project simulates the account for Days starting at from, from its available balance.
+// subscriptions are the user's recurring charges plus income from DetectIncome; ones history
+// shows on a different account are left out, ones with no history are assumed to hit this
+// one.  Charges count at the most they have been and go out before the day's income lands.
+// The running balance is kept as Money in the account's currency
+func (p BalanceProjector) Project(account CowAccount, subscriptions []PossibleSubscriptions, history []CowTransaction, from CivilDate) BalanceProjection {
+	p = p.withDefaults()
+	projection := BalanceProjection{UID: account.UID, AccountID: account.AccountID, From: from, Start: account.Available()}
+	if !account.IsDepository() {
+		return projection
+	}
+	seenOn := subscriptionAccounts(history, p.Location)
+	var mine []PossibleSubscriptions
+	for _, subscription := range subscriptions {
+		if account.UID != "" && subscription.UID != account.UID {
+			continue
+		}
+		if id, ok := seenOn[subscription.chargesKey()]; ok && id != account.AccountID {
+			continue
+		}
+		mine = append(mine, subscription)
+	}
+	for i := 0; i < p.Days; i++ {
+		projection.Days = append(projection.Days, ProjectedBalanceDay{Date: from.AddDays(i)})
+	}
+	for _, charge := range p.Forecaster.Forecast(mine, history, from, p.Days) {
+		day := &projection.Days[0]
+		if offset := from.DaysUntil(charge.Expected); offset > 0 {
+			day = &projection.Days[offset]
+		}
+		if charge.Amount < 0 {
+			day.Income = append(day.Income, charge)
+		} else {
+			day.Charges = append(day.Charges, charge)
+		}
+	}
+	currency := account.CurrencyCode()
+	balance := MoneyFromFloat(projection.Start, currency, RoundHalfEven)
+	lowest := balance
+	projection.LowestOn = from
+	// the first day under the threshold and the first under zero can differ, the alert is
+	// about whichever its kind ends up being
+	var under, overdrawn *ProjectedBalanceDay
+	for i := range projection.Days {
+		day := &projection.Days[i]
+		for _, charge := range day.Charges {
+			balance, _ = balance.Sub(MoneyFromFloat(charge.MaxAmount, currency, RoundHalfEven))
+		}
+		if projection.NextIncome.IsZero() {
+			// only the stretch before payday can be acted on
+			if balance.Minor < lowest.Minor {
+				lowest, projection.LowestOn = balance, day.Date
+			}
+			if under == nil && (balance.IsNegative() || balance.Float64() < p.Threshold) {
+				under = day
+			}
+			if overdrawn == nil && balance.IsNegative() {
+				overdrawn = day
+			}
+		}
+		for _, income := range day.Income {
+			balance, _ = balance.Sub(MoneyFromFloat(income.Amount, currency, RoundHalfEven))
+			if projection.NextIncome.IsZero() {
+				projection.NextIncome = day.Date
+			}
+		}
+		day.Balance = balance.Float64()
+	}
+	projection.Lowest = lowest.Float64()
+	if under != nil {
+		alert := &LowBalanceAlert{
+			Kind:        LowBalanceThreshold,
+			UID:         account.UID,
+			AccountID:   account.AccountID,
+			AccountName: account.Name,
+			Mask:        account.Mask,
+			Date:        under.Date,
+			Balance:     projection.Lowest,
+			LowestOn:    projection.LowestOn,
+			Threshold:   p.Threshold,
+			NextIncome:  projection.NextIncome,
+			Charges:     under.Charges,
+		}
+		if overdrawn != nil {
+			alert.Kind = LowBalanceOverdraft
+			alert.Date, alert.Charges = overdrawn.Date, overdrawn.Charges
+			alert.PastFees, alert.FeeEstimate = overdraftFees(history, account)
+		}
+		projection.Alert = alert
+	}
+	return projection
+}
+
+// AlertQ wraps the projection's alert into an EventAlert queue entry, false when there is none
+func (projection BalanceProjection) AlertQ() (Q, bool) {
+	if projection.Alert == nil {
+		return Q{}, false
+	}
+	extra, _ := json.Marshal(struct {
+		LowBalance LowBalanceAlert `json:"low_balance"`
+	}{*projection.Alert})
+	return Q{
+		Added: time.Now().UTC(),
+		UID:   projection.UID,
+		Event: EventAlert,
+		Extra: string(extra),
+	}, true
+}
+
+// ProjectBalances runs a default BalanceProjector over every depository account, income is
+// detected from history
+func ProjectBalances(accounts []CowAccount, subscriptions []PossibleSubscriptions, history []CowTransaction, from CivilDate, threshold float64) []BalanceProjection {
+	projector := BalanceProjector{Threshold: threshold}
+	all := append(append([]PossibleSubscriptions(nil), subscriptions...), DetectIncome(history)...)
+	var out []BalanceProjection
+	for _, account := range accounts {
+		if account.IsDepository() {
+			out = append(out, projector.Project(account, all, history, from))
+		}
+	}
+	return out
+}
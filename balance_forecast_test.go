@@ -0,0 +1,176 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// paycheckAndRent is four months of rent on the 5th and pay on the 20th
+func paycheckAndRent(t *testing.T) ([]CowTransaction, []PossibleSubscriptions) {
+	t.Helper()
+	history := append(charges(t, "Landlord LLC", 1200, "2023-01-05", 4, monthly),
+		charges(t, "ACME PAYROLL", -2000, "2023-01-20", 4, monthly)...)
+	return history, append(DetectSubscriptions(history), DetectIncome(history)...)
+}
+
+func checkingWith(available float64) CowAccount {
+	return CowAccount{AccountID: "checking", UID: "u1", Name: "Checking", Mask: "0042", Type: AccountTypeDepository,
+		CurrentBalance: available, AvailableBalance: float(available), IsoCurrencyCode: "USD"}
+}
+
+func TestProjectOverdraft(t *testing.T) {
+	history, subscriptions := paycheckAndRent(t)
+	projection := BalanceProjector{}.Project(checkingWith(500), subscriptions, history, civilDay(t, "2023-05-01"))
+	if len(projection.Days) != 30 || projection.Days[4].Balance != -700 || projection.Days[19].Balance != 1300 {
+		t.Fatalf("days %+v", projection.Days)
+	}
+	alert := projection.Alert
+	if alert == nil || alert.Kind != LowBalanceOverdraft || alert.Date.String() != "2023-05-05" || alert.Balance != -700 {
+		t.Fatalf("alert %+v", alert)
+	}
+	if alert.NextIncome.String() != "2023-05-20" || alert.FeeEstimate != TypicalOverdraftFee || alert.PastFees != 0 || alert.Mask != "0042" {
+		t.Errorf("alert %+v", alert)
+	}
+	if len(alert.Charges) != 1 || alert.Charges[0].Name != "Landlord LLC" {
+		t.Errorf("charges %+v", alert.Charges)
+	}
+	q, ok := projection.AlertQ()
+	var extra struct {
+		LowBalance LowBalanceAlert `json:"low_balance"`
+	}
+	if err := json.Unmarshal([]byte(q.Extra), &extra); !ok || err != nil || extra.LowBalance.Kind != LowBalanceOverdraft || q.Event != EventAlert {
+		t.Errorf("queue %+v %v", q, err)
+	}
+}
+
+func TestProjectPastOverdraftFees(t *testing.T) {
+	history, subscriptions := paycheckAndRent(t)
+	for date, amount := range map[string]float64{"2023-02-01": 25, "2023-03-01": 27} {
+		history = append(history, CowTransaction{TransactionID: "fee-" + date, UID: "u1", AccountID: "checking",
+			CategoryID: OverdraftCategoryID, Name: "OVERDRAFT FEE", Amount: amount, Date: date})
+	}
+	alert := BalanceProjector{}.Project(checkingWith(500), subscriptions, history, civilDay(t, "2023-05-01")).Alert
+	if alert == nil || alert.PastFees != 2 || alert.FeeEstimate != 26 {
+		t.Errorf("alert %+v", alert)
+	}
+}
+
+func TestProjectThresholdAndPayday(t *testing.T) {
+	history, subscriptions := paycheckAndRent(t)
+	from := civilDay(t, "2023-05-01")
+	alert := BalanceProjector{Threshold: 500}.Project(checkingWith(1500), subscriptions, history, from).Alert
+	if alert == nil || alert.Kind != LowBalanceThreshold || alert.Balance != 300 || alert.FeeEstimate != 0 {
+		t.Errorf("threshold alert %+v", alert)
+	}
+	if alert := (BalanceProjector{}).Project(checkingWith(1500), subscriptions, history, from).Alert; alert != nil {
+		t.Errorf("no threshold still warned %+v", alert)
+	}
+	// june's rent would overdraw it but that's after payday
+	projection := BalanceProjector{Days: 40}.Project(checkingWith(1300), subscriptions, history, civilDay(t, "2023-05-10"))
+	if projection.Alert != nil || projection.Lowest != 1300 {
+		t.Errorf("after payday %+v", projection.Alert)
+	}
+}
+
+func TestProjectThresholdThenOverdraft(t *testing.T) {
+	history := append(charges(t, "Landlord LLC", 100, "2023-01-03", 4, monthly), charges(t, "Gym", 100, "2023-01-10", 4, monthly)...)
+	alert := BalanceProjector{Threshold: 100}.Project(checkingWith(150), DetectSubscriptions(history), history, civilDay(t, "2023-05-01")).Alert
+	if alert == nil || alert.Kind != LowBalanceOverdraft || alert.Balance != -50 {
+		t.Fatalf("alert %+v", alert)
+	}
+	// under the threshold on the 3rd, but the gym is what overdraws it
+	if alert.Date.String() != "2023-05-10" || len(alert.Charges) != 1 || alert.Charges[0].Name != "Gym" {
+		t.Errorf("overdraft on %s from %+v", alert.Date, alert.Charges)
+	}
+}
+
+func TestProjectExactCents(t *testing.T) {
+	history := append(charges(t, "Hulu", 0.1, "2023-01-05", 4, monthly), charges(t, "Peacock", 0.2, "2023-01-05", 4, monthly)...)
+	projection := BalanceProjector{}.Project(checkingWith(0.3), DetectSubscriptions(history), history, civilDay(t, "2023-05-01"))
+	if projection.Alert != nil || projection.Lowest != 0 {
+		t.Errorf("0.3 - 0.1 - 0.2 overdrew %+v", projection.Alert)
+	}
+}
+
+func TestProjectSkipsOtherAccounts(t *testing.T) {
+	history, subscriptions := paycheckAndRent(t)
+	for i := range history {
+		if history[i].Amount > 0 {
+			history[i].AccountID = "savings"
+		}
+	}
+	if alert := (BalanceProjector{}).Project(checkingWith(500), subscriptions, history, civilDay(t, "2023-05-01")).Alert; alert != nil {
+		t.Errorf("rent from savings warned about checking %+v", alert)
+	}
+	card := CowAccount{AccountID: "card", UID: "u1", Type: AccountTypeCredit}
+	if projection := (BalanceProjector{}).Project(card, subscriptions, history, civilDay(t, "2023-05-01")); len(projection.Days) != 0 {
+		t.Errorf("projected a credit card %+v", projection)
+	}
+}
+
+func TestProjectBalances(t *testing.T) {
+	history := append(charges(t, "Landlord LLC", 1200, "2023-01-05", 4, monthly),
+		charges(t, "ACME PAYROLL", -2000, "2023-01-20", 4, monthly)...)
+	accounts := []CowAccount{checkingWith(500), {AccountID: "card", UID: "u1", Type: AccountTypeCredit}}
+	projections := ProjectBalances(accounts, DetectSubscriptions(history), history, civilDay(t, "2023-05-01"), 0)
+	if len(projections) != 1 || projections[0].NextIncome.String() != "2023-05-20" || projections[0].Alert == nil {
+		t.Errorf("projections %+v", projections)
+	}
+}
+
+func TestDetectIncome(t *testing.T) {
+	history := charges(t, "ACME PAYROLL", -2500, "2023-01-31", 6, monthly)
+	if len(DetectSubscriptions(history)) != 0 {
+		t.Error("deposits detected as subscriptions")
+	}
+	income := DetectIncome(history)
+	if len(income) != 1 || income[0].Amount != -2500 || income[0].Period != PeriodMonthly {
+		t.Fatalf("income %+v", income)
+	}
+	if history[0].Amount != -2500 {
+		t.Error("DetectIncome changed the history")
+	}
+}
+
+func TestDetectIncomeBiweekly(t *testing.T) {
+	history := charges(t, "ACME PAYROLL", -1000, "2023-01-06", 10, func(from CivilDate, n int) CivilDate {
+		return from.AddDays(14 * n)
+	})
+	income := DetectIncome(history)
+	if len(income) != 1 || income[0].Period != PeriodBiweekly || income[0].ExpectedNext().String() != "2023-05-26" {
+		t.Fatalf("income %+v", income)
+	}
+}
+
+func TestDetectIncomeSemimonthly(t *testing.T) {
+	// the 1st and the 15th, gaps of 13 to 17 days
+	history := charges(t, "ACME PAYROLL", -1000, "2023-01-01", 10, func(from CivilDate, n int) CivilDate {
+		return from.AddMonths(n / 2).AddDays(14 * (n % 2))
+	})
+	income := DetectIncome(history)
+	if len(income) != 1 || income[0].Period != PeriodSemimonthly || income[0].Confidence < 0.9 {
+		t.Fatalf("income %+v", income)
+	}
+	if got := PeriodSemimonthly.Step(civilDay(t, "2023-01-20"), 3).String(); got != "2023-03-05" {
+		t.Errorf("step %s", got)
+	}
+	if got := PeriodSemimonthly.Next(civilDay(t, "2023-02-14")).String(); got != "2023-02-28" {
+		t.Errorf("next %s", got)
+	}
+}
+
+func TestProjectBiweeklyPay(t *testing.T) {
+	history := append(charges(t, "Landlord LLC", 1200, "2023-01-05", 5, monthly),
+		charges(t, "ACME PAYROLL", -1000, "2023-01-06", 10, func(from CivilDate, n int) CivilDate {
+			return from.AddDays(14 * n)
+		})...)
+	subscriptions := append(DetectSubscriptions(history), DetectIncome(history)...)
+	projection := BalanceProjector{}.Project(checkingWith(100), subscriptions, history, civilDay(t, "2023-05-29"))
+	if projection.Days[7].Balance != -1100 || projection.Days[11].Balance != -100 {
+		t.Fatalf("days %+v", projection.Days)
+	}
+	alert := projection.Alert
+	if alert == nil || alert.Kind != LowBalanceOverdraft || alert.Date.String() != "2023-06-05" || alert.NextIncome.String() != "2023-06-09" {
+		t.Errorf("alert %+v", alert)
+	}
+}
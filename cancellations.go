@@ -0,0 +1,178 @@
+package spacecow_common
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//go:embed data/cancellations.csv
+var embeddedCancellations []byte
+
+var defaultCancellationDirectory = mustParseCancellationDirectory(embeddedCancellations)
+
+var cancellationHeader = []string{"merchant", "name", "kind", "cancel_url", "hint", "icon"}
+
+var iconTypeNames = enumNames[XActionIconType]{kind: "icon", names: map[XActionIconType]string{
+	XActionIconNeutral: "neutral",
+	XActionIconOk:      "ok",
+	XActionIconWarning: "warning",
+	XActionIconSmart:   "smart",
+	XActionIconCow:     "cow",
+}}
+
+func (icon XActionIconType) String() string {
+	return iconTypeNames.name(icon)
+}
+
+// ParseXActionIconType reads the icon names used in the data files
+func ParseXActionIconType(name string) (XActionIconType, error) {
+	return iconTypeNames.parse(name)
+}
+
+// CancellationEntry is what we know about getting out of one merchant's subscription
+type CancellationEntry struct {
+	Merchant  string          `json:"merchant" bson:"merchant"` // normalized, what subscriptions are matched on
+	Name      string          `json:"name" bson:"name"`         // canonical display name
+	Kind      string          `json:"kind" bson:"kind"`
+	CancelURL string          `json:"cancel_url" bson:"cancelUrl"`
+	Hint      string          `json:"hint" bson:"hint"` // cheaper plan or gotcha
+	Icon      XActionIconType `json:"icon" bson:"icon"`
+}
+
+// CancellationDirectory is the list of well known subscription merchants
+type CancellationDirectory struct {
+	Version string
+	entries map[string]CancellationEntry
+}
+
+// DefaultCancellationDirectory is the directory embedded in this package
+func DefaultCancellationDirectory() *CancellationDirectory {
+	return defaultCancellationDirectory
+}
+
+// ParseCancellationDirectory reads a merchant,name,kind,cancel_url,hint,icon csv
+func ParseCancellationDirectory(r io.Reader) (*CancellationDirectory, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := newDataFileReader(raw, cancellationHeader)
+	if err != nil {
+		return nil, fmt.Errorf("cancellations %w", err)
+	}
+	d := &CancellationDirectory{Version: dataFileVersion(raw), entries: map[string]CancellationEntry{}}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		merchant := normalizeMerchantText(record[0])
+		if merchant == "" {
+			return nil, fmt.Errorf("cancellations: empty merchant for %q", record[1])
+		}
+		if _, dup := d.entries[merchant]; dup {
+			return nil, fmt.Errorf("cancellations: duplicate merchant %s", merchant)
+		}
+		icon, err := ParseXActionIconType(record[5])
+		if err != nil {
+			return nil, fmt.Errorf("cancellations %s: %w", merchant, err)
+		}
+		d.entries[merchant] = CancellationEntry{
+			Merchant:  merchant,
+			Name:      strings.TrimSpace(record[1]),
+			Kind:      strings.TrimSpace(record[2]),
+			CancelURL: strings.TrimSpace(record[3]),
+			Hint:      strings.TrimSpace(record[4]),
+			Icon:      icon,
+		}
+	}
+	return d, nil
+}
+
+// OpenCancellationDirectory loads a directory from disk so it can be updated without a release
+func OpenCancellationDirectory(path string) (*CancellationDirectory, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseCancellationDirectory(f)
+}
+
+func mustParseCancellationDirectory(raw []byte) *CancellationDirectory {
+	d, err := ParseCancellationDirectory(bytes.NewReader(raw))
+	if err != nil {
+		panic("embedded cancellation directory: " + err.Error())
+	}
+	return d
+}
+
+// Lookup finds the entry for a merchant name, exactly or as the longest directory merchant
+// inside it so "netflix com" still finds netflix
+func (d *CancellationDirectory) Lookup(merchant string) (CancellationEntry, bool) {
+	if found, ok := d.entries[NormalizeMerchant(merchant).Key]; ok {
+		return found, true
+	}
+	merchant = normalizeMerchantText(merchant)
+	if found, ok := d.entries[merchant]; ok {
+		return found, true
+	}
+	best := ""
+	for name := range d.entries {
+		longer := len(name) > len(best) || (len(name) == len(best) && name < best)
+		if longer && containsWords(merchant, name) {
+			best = name
+		}
+	}
+	if best == "" {
+		return CancellationEntry{}, false
+	}
+	return d.entries[best], true
+}
+
+// Match finds the entry for a detected subscription - merchant key first, then the names
+func (d *CancellationDirectory) Match(subscription PossibleSubscriptions) (CancellationEntry, bool) {
+	for _, candidate := range []string{subscription.MerchantKey, subscription.MerchantName, subscription.Name, subscription.OriginalDescription} {
+		if candidate == "" {
+			continue
+		}
+		if found, ok := d.Lookup(candidate); ok {
+			return found, true
+		}
+	}
+	return CancellationEntry{}, false
+}
+
+// Enrich fills in the display name, ActionURL, Hint and CowIcon from the directory.  A Hint or
+// ActionURL already set by someone else is kept
+func (d *CancellationDirectory) Enrich(subscription *PossibleSubscriptions) bool {
+	found, ok := d.Match(*subscription)
+	if !ok {
+		return false
+	}
+	subscription.Name = found.Name
+	if subscription.ActionURL == "" {
+		subscription.ActionURL = found.CancelURL
+	}
+	if subscription.Hint == "" {
+		subscription.Hint = found.Hint
+	}
+	subscription.CowIcon = found.Icon
+	return true
+}
+
+// EnrichSubscriptions runs the embedded directory over detected subscriptions in place
+func EnrichSubscriptions(subscriptions []PossibleSubscriptions) []PossibleSubscriptions {
+	for i := range subscriptions {
+		DefaultCancellationDirectory().Enrich(&subscriptions[i])
+	}
+	return subscriptions
+}
@@ -0,0 +1,76 @@
+package spacecow_common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCancellationLookup(t *testing.T) {
+	d := DefaultCancellationDirectory()
+	for _, name := range []string{"Netflix", "NETFLIX.COM", "netflix com 866-579-7172", "SiriusXM Radio"} {
+		if _, ok := d.Lookup(name); !ok {
+			t.Errorf("no entry for %q", name)
+		}
+	}
+	if found, ok := d.Lookup("Disney Plus"); !ok || found.Name != "Disney+" {
+		t.Errorf("disney plus %+v", found)
+	}
+	if found, _ := d.Lookup("siriusxm"); found.Icon != XActionIconWarning {
+		t.Errorf("siriusxm icon %v", found.Icon)
+	}
+	if _, ok := d.Lookup("Joe's Hardware"); ok {
+		t.Error("found an entry for a hardware store")
+	}
+}
+
+func TestEnrichSubscriptions(t *testing.T) {
+	subscriptions := EnrichSubscriptions([]PossibleSubscriptions{
+		{Name: "NETFLIX.COM", MerchantKey: "netflix"},
+		{Name: "Hulu", Hint: "already set", ActionURL: "https://example.com"},
+		{Name: "Corner Bakery"},
+	})
+	netflix := subscriptions[0]
+	if netflix.Name != "Netflix" || !strings.HasPrefix(netflix.ActionURL, "https://www.netflix.com") || netflix.Hint == "" || netflix.CowIcon != XActionIconSmart {
+		t.Errorf("netflix %+v", netflix)
+	}
+	if hulu := subscriptions[1]; hulu.Hint != "already set" || hulu.ActionURL != "https://example.com" || hulu.CowIcon != XActionIconSmart {
+		t.Errorf("hulu %+v", hulu)
+	}
+	if bakery := subscriptions[2]; bakery.ActionURL != "" || bakery.CowIcon != XActionIconNeutral {
+		t.Errorf("bakery %+v", bakery)
+	}
+}
+
+func TestParseCancellationDirectory(t *testing.T) {
+	good := "# version: 7\nmerchant,name,kind,cancel_url,hint,icon\nAcme TV,Acme,streaming,https://acme.tv, ,Warning\n"
+	d, err := ParseCancellationDirectory(strings.NewReader(good))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found, ok := d.Lookup("ACME TV"); d.Version != "7" || !ok || found.Icon != XActionIconWarning || found.Hint != "" {
+		t.Errorf("%s %+v", d.Version, found)
+	}
+	bad := []string{
+		"merchant,name,kind,cancel_url,hint,icon\nacme,Acme,streaming,,,sparkly\n",
+		"merchant,name,kind,cancel_url,hint,icon\nacme,Acme,streaming,,,ok\nACME,Acme,streaming,,,ok\n",
+		"merchant,name,kind,cancel_url,hint,icon\n--,Acme,streaming,,,ok\n",
+		"merchant,name,kind,url,hint,icon\n",
+	}
+	for _, raw := range bad {
+		if _, err := ParseCancellationDirectory(strings.NewReader(raw)); err == nil {
+			t.Errorf("accepted %q", raw)
+		}
+	}
+}
+
+func TestXActionIconNames(t *testing.T) {
+	for icon := XActionIconNeutral; icon <= XActionIconCow; icon++ {
+		parsed, err := ParseXActionIconType(" " + strings.ToUpper(XActionIconType(icon).String()))
+		if err != nil || parsed != XActionIconType(icon) {
+			t.Errorf("%d: %v %v", icon, parsed, err)
+		}
+	}
+	if XActionIconType(42).String() != "icon(42)" {
+		t.Error(XActionIconType(42).String())
+	}
+}
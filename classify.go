@@ -0,0 +1,156 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ClassificationSource is which classifier made the call
+type ClassificationSource int
+
+const (
+	SourceUnknown ClassificationSource = iota
+	SourceCategoryID
+	SourcePFC
+	SourceOverride
+	SourceMerchant
+	SourceModel
+)
+
+var classificationSourceNames = enumNames[ClassificationSource]{kind: "source", names: map[ClassificationSource]string{
+	SourceUnknown:    "unknown",
+	SourceCategoryID: "category_id",
+	SourcePFC:        "pfc",
+	SourceOverride:   "override",
+	SourceMerchant:   "merchant",
+	SourceModel:      "model",
+}}
+
+func (s ClassificationSource) String() string {
+	return classificationSourceNames.name(s)
+}
+
+// MarshalJSON writes the source name so logs and the ui don't need the enum
+func (s ClassificationSource) MarshalJSON() ([]byte, error) {
+	return classificationSourceNames.marshalJSON(s)
+}
+
+// UnmarshalJSON reads a source name written by MarshalJSON
+func (s *ClassificationSource) UnmarshalJSON(raw []byte) error {
+	return classificationSourceNames.unmarshalJSON(raw, s)
+}
+
+// DefaultModelConfidence is how sure the text model has to be before we take its word
+const DefaultModelConfidence = 0.6
+
+// confidence we give plaid's own pfc confidence levels
+var pfcConfidence = map[string]float64{
+	"VERY_HIGH": 0.98,
+	"HIGH":      0.9,
+	"MEDIUM":    0.7,
+	"LOW":       0.5,
+}
+
+// Classification is a TransactionMap plus why we picked it
+type Classification struct {
+	Map             TransactionMap       `json:"map" bson:"map"`
+	Source          ClassificationSource `json:"source" bson:"source"`
+	Matched         string               `json:"matched" bson:"matched"` // category id, pfc, rule id, merchant/keyword or model class
+	Confidence      float64              `json:"confidence" bson:"confidence"`
+	TaxonomyVersion string               `json:"taxonomy_version" bson:"taxonomyVersion"`
+}
+
+func (c Classification) String() string {
+	return fmt.Sprintf("%s via %s (%s) confidence %.2f taxonomy %s",
+		c.Map.DetailedDescription, c.Source, c.Matched, c.Confidence, c.TaxonomyVersion)
+}
+
+// IntrospectQ wraps the explanation of a transaction's classification into an EventIntrospect queue entry
+func (c Classification) IntrospectQ(transaction CowTransaction) Q {
+	extra, _ := json.Marshal(struct {
+		TransactionID  string         `json:"transaction_id"`
+		Classification Classification `json:"classification"`
+	}{transaction.TransactionID, c})
+	return Q{
+		Added: time.Now().UTC(),
+		UID:   transaction.UID,
+		Event: EventIntrospect,
+		Extra: string(extra),
+	}
+}
+
+// Classifier runs the whole classification chain - override rules, pfc, the category id table,
+// the merchant dictionary and finally the text model.  Any part left nil is skipped
+type Classifier struct {
+	Overrides       *OverrideRuleSet
+	Model           *TextModel
+	ModelConfidence float64 // zero means DefaultModelConfidence
+}
+
+// Explain classifies and says why
+func (c *Classifier) Explain(transaction CowTransaction) Classification {
+	version := DefaultTaxonomy().Version
+	explain := func(found TransactionMap, source ClassificationSource, matched string, confidence float64) Classification {
+		return Classification{Map: found, Source: source, Matched: matched, Confidence: confidence, TaxonomyVersion: version}
+	}
+	if rule, ok := c.Overrides.Match(OverrideBefore, transaction, transaction.CategoryID); ok {
+		return explain(applyOverride(rule, DetailedClassify(transaction), transaction.Amount), SourceOverride, rule.ID, 1)
+	}
+	result := c.explainBase(transaction, explain)
+	if rule, ok := c.Overrides.Match(OverrideAfter, transaction, result.Map.ID); ok {
+		return explain(applyOverride(rule, result.Map, transaction.Amount), SourceOverride, rule.ID, 1)
+	}
+	return result
+}
+
+func (c *Classifier) explainBase(transaction CowTransaction,
+	explain func(TransactionMap, ClassificationSource, string, float64) Classification) Classification {
+	if found, ok := ClassifyPFC(transaction); ok {
+		pfc, _ := transaction.PFC()
+		confidence, ok := pfcConfidence[pfc.ConfidenceLevel]
+		if !ok {
+			confidence = pfcConfidence["HIGH"]
+		}
+		matched := pfc.Detailed
+		if matched == "" {
+			matched = pfc.Primary
+		}
+		return explain(found, SourcePFC, matched, confidence)
+	}
+	if _, known := DefaultTaxonomy().Lookup(transaction.CategoryID); known {
+		return explain(DetailedClassify(transaction), SourceCategoryID, transaction.CategoryID, 1)
+	}
+	if match, ok := ClassifyMerchant(transaction); ok {
+		return explain(match.Map, SourceMerchant, match.Matched, match.Confidence)
+	}
+	if c.Model != nil {
+		threshold := c.ModelConfidence
+		if threshold == 0 {
+			threshold = DefaultModelConfidence
+		}
+		if best, ok := c.Model.Best(transaction); ok && best.Probability >= threshold {
+			if found, ok := DefaultTaxonomy().Lookup(best.CategoryID); ok {
+				return explain(withAmountSign(found, transaction.Amount), SourceModel, best.CategoryID, best.Probability)
+			}
+		}
+	}
+	return explain(DetailedClassify(transaction), SourceUnknown, transaction.CategoryID, 0)
+}
+
+// Classify is Explain without the explanation
+func (c *Classifier) Classify(transaction CowTransaction) TransactionMap {
+	return c.Explain(transaction).Map
+}
+
+// ClassifyExplain runs the default chain (no overrides, no model) and says why
+func ClassifyExplain(transaction CowTransaction) Classification {
+	return (&Classifier{}).Explain(transaction)
+}
+
+// Classify is the preferred entry point - plaid's personal finance category when it is present
+// and known, the legacy category id through DetailedClassify otherwise.  When plaid gives us
+// no category we know the merchant dictionary gets a go before settling for "unknown"
+func Classify(transaction CowTransaction) TransactionMap {
+	return ClassifyExplain(transaction).Map
+}
@@ -0,0 +1,84 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestClassifyExplainSources(t *testing.T) {
+	model := NewTextModel()
+	model.TrainFromHistory(modelHistory())
+	classifier := &Classifier{
+		Overrides: &OverrideRuleSet{Rules: []OverrideRule{{ID: "rent", MatchNamePattern: "RENT", SetCategoryID: "18000000"}}},
+		Model:     model,
+	}
+	for _, c := range []struct {
+		name        string
+		transaction CowTransaction
+		source      ClassificationSource
+		matched     string
+	}{
+		{"override", CowTransaction{Name: "RENT MAY", CategoryID: "13000000", Amount: 900}, SourceOverride, "rent"},
+		{"pfc", CowTransaction{PersonalFinanceCategory: `{"primary":"BANK_FEES","detailed":"BANK_FEES_ATM_FEES","confidence_level":"LOW"}`, Amount: 3}, SourcePFC, "BANK_FEES_ATM_FEES"},
+		{"category id", CowTransaction{CategoryID: "13000000", Amount: 3}, SourceCategoryID, "13000000"},
+		{"merchant", CowTransaction{MerchantName: "Netflix", Amount: 15}, SourceMerchant, "netflix"},
+		{"model", CowTransaction{Name: "BLUE BOTTLE", Amount: 5}, SourceModel, "13005043"},
+		{"unknown", CowTransaction{Name: "ZXQV", CategoryID: "x", Amount: 5}, SourceUnknown, "x"},
+	} {
+		got := classifier.Explain(c.transaction)
+		if got.Source != c.source || got.Matched != c.matched || got.TaxonomyVersion != DefaultTaxonomy().Version {
+			t.Errorf("%s: got %s", c.name, got)
+		}
+	}
+	if got := classifier.Explain(CowTransaction{PersonalFinanceCategory: `{"primary":"BANK_FEES","confidence_level":"LOW"}`}); got.Confidence != pfcConfidence["LOW"] {
+		t.Errorf("pfc confidence %v", got.Confidence)
+	}
+}
+
+func TestClassificationSourceJSON(t *testing.T) {
+	raw, err := json.Marshal(SourceMerchant)
+	if err != nil || string(raw) != `"merchant"` {
+		t.Fatalf("got %s %v", raw, err)
+	}
+	var source ClassificationSource
+	if err := json.Unmarshal(raw, &source); err != nil || source != SourceMerchant {
+		t.Fatalf("got %v %v", source, err)
+	}
+	if err := json.Unmarshal([]byte(`"psychic"`), &source); err == nil {
+		t.Fatal("unknown source accepted")
+	}
+	if got := ClassificationSource(42).String(); got != "source(42)" {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestXactionTypeJSON(t *testing.T) {
+	raw, err := json.Marshal(TransactionMap{TransactionType: XactionLateFee})
+	if err != nil || !strings.Contains(string(raw), `"transaction_type":"late_fee"`) {
+		t.Fatalf("got %s %v", raw, err)
+	}
+	var back TransactionMap
+	if err := json.Unmarshal(raw, &back); err != nil || back.TransactionType != XactionLateFee {
+		t.Fatalf("got %v %v", back.TransactionType, err)
+	}
+	// documents written before the names were numbers
+	var old TransactionMap
+	if err := json.Unmarshal([]byte(`{"transaction_type":2}`), &old); err != nil || old.TransactionType != XactionCredit {
+		t.Fatalf("got %v %v", old.TransactionType, err)
+	}
+	if got, err := ParseXactionType(" Charge "); err != nil || got != XactionCharge {
+		t.Errorf("parse %v %v", got, err)
+	}
+	if got := XactionType(42).String(); got != "transaction type(42)" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestIntrospectQ(t *testing.T) {
+	transaction := CowTransaction{TransactionID: "tx", UID: "u", CategoryID: "13000000", Amount: 3}
+	q := ClassifyExplain(transaction).IntrospectQ(transaction)
+	if q.Event != EventIntrospect || q.UID != "u" || !strings.Contains(q.Extra, `"source":"category_id"`) {
+		t.Errorf("got %+v", q)
+	}
+}
@@ -0,0 +1,37 @@
+package spacecow_common
+
+import "testing"
+
+func TestDetailedClassifyTypes(t *testing.T) {
+	for _, c := range []struct {
+		name       string
+		categoryID string
+		amount     float64
+		want       XactionType
+	}{
+		{"interest charged", "15002000", 12, XactionInterestCharge},
+		{"late fee", "10003000", 25, XactionLateFee},
+		{"reversed late fee", "10003000", -25, XactionCredit},
+		{"interest earned", "15001000", -3, XactionCredit},
+		{"generic interest paid to us", "15000000", -3, XactionCredit},
+		{"generic interest charged", "15000000", 3, XactionInterestCharge},
+		{"refund", "19013000", -40, XactionCredit},
+		{"purchase", "19013000", 40, XactionCharge},
+		{"unknown refund", "99999999", -1, XactionCredit},
+	} {
+		if got := DetailedClassify(CowTransaction{CategoryID: c.categoryID, Amount: c.amount}).TransactionType; got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestPaymentsKeepTheirType(t *testing.T) {
+	for _, entry := range DefaultTaxonomy().Entries() {
+		if entry.TransactionType != XactionPayment {
+			continue
+		}
+		if got := DetailedClassify(CowTransaction{CategoryID: entry.ID, Amount: -100}).TransactionType; got != XactionPayment {
+			t.Errorf("%s: a payment in came back as %v", entry.ID, got)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package spacecow_common
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed data/currencies.csv
+var embeddedCurrencies []byte
+
+var currencies = mustParseCurrencies(embeddedCurrencies)
+
+var currencyHeader = []string{"code", "numeric", "minor_units", "symbol", "name"}
+var exchangeRateHeader = []string{"date", "base", "quote", "rate"}
+
+// ErrNoExchangeRate is returned when a rate table can't convert between two currencies
+var ErrNoExchangeRate = errors.New("no exchange rate")
+
+// Currency is ISO-4217 metadata - plaid's unofficial codes (crypto mostly) have no numeric code
+type Currency struct {
+	Code       string `json:"code" bson:"code"`
+	Numeric    string `json:"numeric" bson:"numeric"`
+	MinorUnits int    `json:"minor_units" bson:"minorUnits"`
+	Symbol     string `json:"symbol" bson:"symbol"`
+	Name       string `json:"name" bson:"name"`
+}
+
+func mustParseCurrencies(raw []byte) map[string]Currency {
+	reader, err := newDataFileReader(raw, currencyHeader)
+	if err != nil {
+		panic("embedded currencies: " + err.Error())
+	}
+	out := map[string]Currency{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			panic("embedded currencies: " + err.Error())
+		}
+		digits, err := strconv.Atoi(record[2])
+		if err != nil {
+			panic("embedded currencies: " + record[0] + ": " + err.Error())
+		}
+		out[record[0]] = Currency{Code: record[0], Numeric: record[1], MinorUnits: digits, Symbol: record[3], Name: record[4]}
+	}
+	return out
+}
+
+// LookupCurrency finds the metadata for a currency code
+func LookupCurrency(code string) (Currency, bool) {
+	found, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	return found, ok
+}
+
+// Currencies lists every currency we know, sorted by code
+func Currencies() []Currency {
+	out := make([]Currency, 0, len(currencies))
+	for _, currency := range currencies {
+		out = append(out, currency)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Code < out[j].Code
+	})
+	return out
+}
+
+// MinorUnits is how many decimal places a currency uses, 2 when we don't know better
+func MinorUnits(currency string) int {
+	if found, ok := LookupCurrency(currency); ok {
+		return found.MinorUnits
+	}
+	return 2
+}
+
+// Format is the amount with its currency symbol, "-$12.34" - unknown currencies fall back to String
+func (m Money) Format() string {
+	found, ok := LookupCurrency(m.Currency)
+	if !ok {
+		return m.String()
+	}
+	decimal := m.Abs().Decimal()
+	if m.IsNegative() {
+		return "-" + found.Symbol + decimal
+	}
+	return found.Symbol + decimal
+}
+
+// ExchangeRates converts between currencies as of a day.  Rate is how many units of quote one
+// unit of base buys
+type ExchangeRates interface {
+	Rate(base string, quote string, on time.Time) (float64, error)
+}
+
+// Convert moves money into another currency at the rate for a day.  Amounts with no currency -
+// plaid leaves both codes empty now and then - are taken to already be in the target currency.
+// rates can be nil when nothing actually needs converting
+func Convert(amount Money, to string, on time.Time, rates ExchangeRates) (Money, error) {
+	to = strings.ToUpper(to)
+	if amount.Currency == "" {
+		return ParseMoney(amount.Decimal(), to, RoundHalfEven)
+	}
+	if amount.Currency == to || amount.IsZero() {
+		return NewMoney(amount.Minor, to), nil
+	}
+	if rates == nil {
+		return Money{}, fmt.Errorf("%w: %s to %s, no rate table", ErrNoExchangeRate, amount.Currency, to)
+	}
+	rate, err := rates.Rate(amount.Currency, to, on)
+	if err != nil {
+		return Money{}, err
+	}
+	decimal, ok := new(big.Rat).SetString(amount.Decimal())
+	factor, ok2 := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	if !ok || !ok2 {
+		return Money{}, fmt.Errorf("%w: bad rate %v", ErrNoExchangeRate, rate)
+	}
+	decimal.Mul(decimal, factor)
+	decimal.Mul(decimal, new(big.Rat).SetInt64(pow10(MinorUnits(to))))
+	return NewMoney(roundRat(decimal, RoundHalfEven), to), nil
+}
+
+type datedRate struct {
+	day  string // YYYY-MM-DD sorts as a string
+	rate float64
+}
+
+// FileExchangeRates is a date keyed rate table loaded from a date,base,quote,rate csv.  The
+// rate for a day is the latest one on or before it so weekends and holidays just work.  Inverse
+// pairs are derived, and pairs neither way round in the file are crossed through USD
+type FileExchangeRates struct {
+	Version string
+	pairs   map[string][]datedRate
+}
+
+// ParseExchangeRates reads a rate table csv
+func ParseExchangeRates(r io.Reader) (*FileExchangeRates, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := newDataFileReader(raw, exchangeRateHeader)
+	if err != nil {
+		return nil, fmt.Errorf("exchange rates %w", err)
+	}
+	rates := &FileExchangeRates{Version: dataFileVersion(raw), pairs: map[string][]datedRate{}}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		day, err := time.Parse(civilDateLayout, strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("exchange rates: %w", err)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("exchange rates: bad rate %q on %s", record[3], record[0])
+		}
+		key := ratePair(record[1], record[2])
+		rates.pairs[key] = append(rates.pairs[key], datedRate{day: day.Format(civilDateLayout), rate: rate})
+	}
+	for _, series := range rates.pairs {
+		sort.SliceStable(series, func(i, j int) bool {
+			return series[i].day < series[j].day
+		})
+	}
+	return rates, nil
+}
+
+// OpenExchangeRates loads a rate table from disk
+func OpenExchangeRates(path string) (*FileExchangeRates, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseExchangeRates(f)
+}
+
+func ratePair(base string, quote string) string {
+	return strings.ToUpper(strings.TrimSpace(base)) + "/" + strings.ToUpper(strings.TrimSpace(quote))
+}
+
+func (rates *FileExchangeRates) direct(base string, quote string, day string) (float64, bool) {
+	series := rates.pairs[ratePair(base, quote)]
+	i := sort.Search(len(series), func(i int) bool {
+		return series[i].day > day
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return series[i-1].rate, true
+}
+
+func (rates *FileExchangeRates) either(base string, quote string, day string) (float64, bool) {
+	if rate, ok := rates.direct(base, quote, day); ok {
+		return rate, true
+	}
+	if rate, ok := rates.direct(quote, base, day); ok {
+		return 1 / rate, true
+	}
+	return 0, false
+}
+
+// Rate implements ExchangeRates
+func (rates *FileExchangeRates) Rate(base string, quote string, on time.Time) (float64, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	if base == quote {
+		return 1, nil
+	}
+	day := on.Format(civilDateLayout)
+	if rate, ok := rates.either(base, quote, day); ok {
+		return rate, nil
+	}
+	toUSD, ok := rates.either(base, "USD", day)
+	fromUSD, ok2 := rates.either("USD", quote, day)
+	if ok && ok2 {
+		return toUSD * fromUSD, nil
+	}
+	return 0, fmt.Errorf("%w: %s to %s on %s", ErrNoExchangeRate, base, quote, day)
+}
+
+// ConvertTransaction is the transaction amount in the home currency on the transaction's date
+func ConvertTransaction(transaction CowTransaction, home string, rates ExchangeRates) (Money, error) {
+	return Convert(transaction.Money(), home, transaction.EffectiveDate(time.UTC).In(time.UTC), rates)
+}
+
+// SumTransactions totals transactions in the home currency, each converted on its own date
+func SumTransactions(transactions []CowTransaction, home string, rates ExchangeRates) (Money, error) {
+	total := NewMoney(0, home)
+	for _, transaction := range transactions {
+		converted, err := ConvertTransaction(transaction, home, rates)
+		if err != nil {
+			return Money{}, fmt.Errorf("transaction %s: %w", transaction.TransactionID, err)
+		}
+		if total, err = total.Add(converted); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// flatType is the top level category of a transaction, from the stored introspection when there is one
+func flatType(transaction CowTransaction) string {
+	if path := CategoryPath(transaction.DetailedDescription); len(path) > 0 {
+		return path[0]
+	}
+	return Classify(transaction).Description
+}
+
+// CategoryTotals builds Categories rows per UID and top level category with every amount
+// converted to the home currency first
+func CategoryTotals(transactions []CowTransaction, home string, rates ExchangeRates) ([]Categories, error) {
+	var out []Categories
+	totals := map[string]Money{}
+	at := map[string]int{}
+	for _, transaction := range transactions {
+		converted, err := ConvertTransaction(transaction, home, rates)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", transaction.TransactionID, err)
+		}
+		category := flatType(transaction)
+		key := transaction.UID + "\x00" + category
+		if _, seen := at[key]; !seen {
+			at[key] = len(out)
+			out = append(out, Categories{UID: transaction.UID, FlatType: category})
+			totals[key] = NewMoney(0, home)
+		}
+		if totals[key], err = totals[key].Add(converted); err != nil {
+			return nil, err
+		}
+	}
+	for key, i := range at {
+		out[i].SetMoney(totals[key])
+	}
+	return out, nil
+}
@@ -0,0 +1,136 @@
+package spacecow_common
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testRates = `# version: test.1
+date,base,quote,rate
+2023-01-02,EUR,USD,1.10
+2023-02-01,EUR,USD,1.20
+2023-01-02,USD,JPY,130
+`
+
+func testExchangeRates(t *testing.T) *FileExchangeRates {
+	t.Helper()
+	rates, err := ParseExchangeRates(strings.NewReader(testRates))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rates
+}
+
+func TestExchangeRateLookup(t *testing.T) {
+	rates := testExchangeRates(t)
+	if rates.Version != "test.1" {
+		t.Errorf("version %q", rates.Version)
+	}
+	cases := []struct {
+		base, quote string
+		on          string
+		want        float64
+	}{
+		{"EUR", "USD", "2023-01-15", 1.10}, // latest on or before
+		{"EUR", "USD", "2023-02-01", 1.20},
+		{"usd", "eur", "2023-02-05", 1 / 1.20},   // inverse
+		{"EUR", "JPY", "2023-01-02", 1.10 * 130}, // crossed through USD
+		{"USD", "USD", "2000-01-01", 1},
+	}
+	for _, c := range cases {
+		on, _ := time.Parse(civilDateLayout, c.on)
+		got, err := rates.Rate(c.base, c.quote, on)
+		if err != nil || got != c.want {
+			t.Errorf("%s/%s on %s: %v %v, want %v", c.base, c.quote, c.on, got, err, c.want)
+		}
+	}
+	before, _ := time.Parse(civilDateLayout, "2022-12-31")
+	if _, err := rates.Rate("EUR", "USD", before); !errors.Is(err, ErrNoExchangeRate) {
+		t.Errorf("rate before the table: %v", err)
+	}
+}
+
+func TestParseExchangeRatesRejectsBadRates(t *testing.T) {
+	bad := []string{
+		"date,base,quote,rate\n2023-01-02,EUR,USD,0\n",
+		"date,base,quote,rate\n2023-01-02,EUR,USD,x\n",
+		"date,base,quote,rate\nJan 2,EUR,USD,1.1\n",
+		"day,base,quote,rate\n",
+	}
+	for _, raw := range bad {
+		if _, err := ParseExchangeRates(strings.NewReader(raw)); err == nil {
+			t.Errorf("accepted %q", raw)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	rates := testExchangeRates(t)
+	on, _ := time.Parse(civilDateLayout, "2023-01-10")
+	got, err := Convert(NewMoney(1000, "EUR"), "usd", on, rates)
+	if err != nil || got != NewMoney(1100, "USD") {
+		t.Errorf("10 EUR: %v %v", got, err)
+	}
+	got, err = Convert(NewMoney(1234, "USD"), "JPY", on, rates)
+	if err != nil || got != NewMoney(1604, "JPY") {
+		t.Errorf("12.34 USD: %v %v", got, err)
+	}
+	if _, err = Convert(NewMoney(100, "GBP"), "USD", on, rates); !errors.Is(err, ErrNoExchangeRate) {
+		t.Errorf("unknown pair: %v", err)
+	}
+	if _, err = Convert(NewMoney(100, "EUR"), "USD", on, nil); !errors.Is(err, ErrNoExchangeRate) {
+		t.Errorf("nil rates: %v", err)
+	}
+	got, err = Convert(NewMoney(100, "EUR"), "EUR", on, nil)
+	if err != nil || got != NewMoney(100, "EUR") {
+		t.Errorf("same currency: %v %v", got, err)
+	}
+}
+
+func TestConvertWithoutCurrencyIsHome(t *testing.T) {
+	got, err := Convert(NewMoney(1250, ""), "USD", time.Now(), nil)
+	if err != nil || got != NewMoney(1250, "USD") {
+		t.Errorf("USD: %v %v", got, err)
+	}
+	got, err = Convert(NewMoney(1250, ""), "KWD", time.Now(), nil)
+	if err != nil || got != NewMoney(12500, "KWD") {
+		t.Errorf("KWD: %v %v", got, err)
+	}
+}
+
+func TestSumTransactionsWithMissingCurrency(t *testing.T) {
+	rates := testExchangeRates(t)
+	transactions := []CowTransaction{
+		{TransactionID: "a", Amount: 10, IsoCurrencyCode: "EUR", Date: "2023-01-10"},
+		{TransactionID: "b", Amount: 2.5, Date: "2023-01-10"}, // plaid sent no currency at all
+		{TransactionID: "c", Amount: 1.25, IsoCurrencyCode: "USD", Date: "2023-01-10"},
+	}
+	total, err := SumTransactions(transactions, "USD", rates)
+	if err != nil || total != NewMoney(1475, "USD") {
+		t.Errorf("total %v %v", total, err)
+	}
+}
+
+func TestCategoryTotals(t *testing.T) {
+	rates := testExchangeRates(t)
+	coffee := CowTransaction{UID: "u1", CategoryID: "13005043", Amount: 10, IsoCurrencyCode: "EUR", Date: "2023-01-10"}
+	more := coffee
+	more.Amount, more.IsoCurrencyCode = 4, ""
+	other := coffee
+	other.UID = "u2"
+	totals, err := CategoryTotals([]CowTransaction{coffee, more, other}, "USD", rates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(totals) != 2 {
+		t.Fatalf("got %+v", totals)
+	}
+	if totals[0].UID != "u1" || totals[0].Total != 15 || totals[0].FlatType == "" {
+		t.Errorf("u1 %+v", totals[0])
+	}
+	if totals[1].UID != "u2" || totals[1].Total != 11 {
+		t.Errorf("u2 %+v", totals[1])
+	}
+}
@@ -0,0 +1,264 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// civilDateLayout is how plaid writes dates
+const civilDateLayout = "2006-01-02"
+
+// CivilDate is a calendar day with no time or zone, what plaid means by "YYYY-MM-DD".  It
+// marshals to json and bson as that same string so it can replace the string date fields
+type CivilDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseCivilDate reads "YYYY-MM-DD"
+func ParseCivilDate(value string) (CivilDate, error) {
+	t, err := time.Parse(civilDateLayout, strings.TrimSpace(value))
+	if err != nil {
+		return CivilDate{}, fmt.Errorf("civil date: %w", err)
+	}
+	return CivilDateOf(t), nil
+}
+
+// CivilDateOf is the calendar day of t in t's own location
+func CivilDateOf(t time.Time) CivilDate {
+	year, month, day := t.Date()
+	return CivilDate{Year: year, Month: month, Day: day}
+}
+
+// IsZero is true for the unset date
+func (d CivilDate) IsZero() bool {
+	return d == CivilDate{}
+}
+
+func (d CivilDate) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// In is midnight at the start of the day in loc
+func (d CivilDate) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays moves the date, normalizing across months and years
+func (d CivilDate) AddDays(days int) CivilDate {
+	return CivilDateOf(d.In(time.UTC).AddDate(0, 0, days))
+}
+
+// AddMonths moves the date by whole months, clamping to the end of shorter months so
+// Jan 31 + 1 month is Feb 28 and not Mar 3
+func (d CivilDate) AddMonths(months int) CivilDate {
+	first := time.Date(d.Year, d.Month, 1, 0, 0, 0, 0, time.UTC).AddDate(0, months, 0)
+	day := d.Day
+	if last := daysIn(first.Year(), first.Month()); day > last {
+		day = last
+	}
+	return CivilDate{Year: first.Year(), Month: first.Month(), Day: day}
+}
+
+// Weekday of the date
+func (d CivilDate) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// Before is true when d is an earlier day than other
+func (d CivilDate) Before(other CivilDate) bool {
+	return d.In(time.UTC).Before(other.In(time.UTC))
+}
+
+// After is true when d is a later day than other
+func (d CivilDate) After(other CivilDate) bool {
+	return other.Before(d)
+}
+
+// DaysUntil is the number of days from d to other, negative when other is earlier
+func (d CivilDate) DaysUntil(other CivilDate) int {
+	return int(other.In(time.UTC).Sub(d.In(time.UTC)).Hours() / 24)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// MarshalJSON writes "YYYY-MM-DD", the zero date is an empty string
+func (d CivilDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON reads "YYYY-MM-DD", empty strings and null are the zero date
+func (d *CivilDate) UnmarshalJSON(raw []byte) error {
+	var value *string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("civil date: %w", err)
+	}
+	return d.set(value)
+}
+
+// MarshalBSONValue stores the date as a "YYYY-MM-DD" string like the plaid fields
+func (d CivilDate) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(d.String())
+}
+
+// UnmarshalBSONValue reads a "YYYY-MM-DD" string, a bson datetime, or null
+func (d *CivilDate) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bsontype.String:
+		text := value.StringValue()
+		return d.set(&text)
+	case bsontype.DateTime:
+		*d = CivilDateOf(value.Time().UTC())
+		return nil
+	case bsontype.Null:
+		return d.set(nil)
+	}
+	return fmt.Errorf("civil date: can't read bson %s", t)
+}
+
+func (d *CivilDate) set(value *string) error {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		*d = CivilDate{}
+		return nil
+	}
+	parsed, err := ParseCivilDate(*value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// EffectiveTime is when the money actually moved as far as the user is concerned.  Precedence:
+//
+//  1. AuthorizedDatetime - the swipe itself
+//  2. AuthorizedDate - the swipe day, midnight in loc
+//  3. Datetime - when it posted
+//  4. Date - the posting day (or the transaction day while pending), midnight in loc
+//
+// exact is false when the time of day is made up, i.e. rules 2 and 4.  A transaction with
+// none of the four returns the zero time
+func (transaction CowTransaction) EffectiveTime(loc *time.Location) (effective time.Time, exact bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if !transaction.AuthorizedDatetime.IsZero() {
+		return transaction.AuthorizedDatetime.In(loc), true
+	}
+	if day, err := ParseCivilDate(transaction.AuthorizedDate); err == nil {
+		return day.In(loc), false
+	}
+	if !transaction.Datetime.IsZero() {
+		return transaction.Datetime.In(loc), true
+	}
+	if day, err := ParseCivilDate(transaction.Date); err == nil {
+		return day.In(loc), false
+	}
+	return time.Time{}, false
+}
+
+// EffectiveDate is the day of EffectiveTime in the user's time zone
+func (transaction CowTransaction) EffectiveDate(loc *time.Location) CivilDate {
+	effective, _ := transaction.EffectiveTime(loc)
+	if effective.IsZero() {
+		return CivilDate{}
+	}
+	return CivilDateOf(effective)
+}
+
+// BucketPeriod is how transactions are grouped over time
+type BucketPeriod int
+
+const (
+	BucketDay BucketPeriod = iota
+	BucketWeek
+	BucketMonth
+	BucketBillingCycle
+)
+
+// Bucketer puts dates into periods the same way everywhere.  Location is the user's time zone
+// (UTC when nil), WeekStart the first day of a week and BillingDay the day of the month a
+// billing cycle starts on - days past the end of a short month fall on its last day
+type Bucketer struct {
+	Location   *time.Location
+	WeekStart  time.Weekday
+	BillingDay int
+}
+
+// Bucket is the [start, end) range of the period holding d
+func (b Bucketer) Bucket(d CivilDate, period BucketPeriod) (start CivilDate, end CivilDate) {
+	switch period {
+	case BucketWeek:
+		back := (int(d.Weekday()) - int(b.WeekStart) + 7) % 7
+		start = d.AddDays(-back)
+		return start, start.AddDays(7)
+	case BucketMonth:
+		start = CivilDate{Year: d.Year, Month: d.Month, Day: 1}
+		return start, start.AddMonths(1)
+	case BucketBillingCycle:
+		billingDay := b.BillingDay
+		if billingDay < 1 {
+			billingDay = 1
+		}
+		cycleStart := func(year int, month time.Month) CivilDate {
+			day := billingDay
+			if last := daysIn(year, month); day > last {
+				day = last
+			}
+			return CivilDate{Year: year, Month: month, Day: day}
+		}
+		start = cycleStart(d.Year, d.Month)
+		if d.Before(start) {
+			previous := CivilDate{Year: d.Year, Month: d.Month, Day: 1}.AddMonths(-1)
+			start = cycleStart(previous.Year, previous.Month)
+		}
+		next := CivilDate{Year: start.Year, Month: start.Month, Day: 1}.AddMonths(1)
+		return start, cycleStart(next.Year, next.Month)
+	}
+	return d, d.AddDays(1)
+}
+
+// TransactionBucket is one period's worth of transactions
+type TransactionBucket struct {
+	Start        CivilDate        `json:"start" bson:"start"`
+	End          CivilDate        `json:"end" bson:"end"` // exclusive
+	Transactions []CowTransaction `json:"transactions" bson:"transactions"`
+}
+
+// Group buckets transactions by their EffectiveDate in the user's zone, oldest period first.
+// Transactions without any date are left out
+func (b Bucketer) Group(transactions []CowTransaction, period BucketPeriod) []TransactionBucket {
+	byStart := map[CivilDate]int{}
+	var out []TransactionBucket
+	for _, transaction := range transactions {
+		day := transaction.EffectiveDate(b.Location)
+		if day.IsZero() {
+			continue
+		}
+		start, end := b.Bucket(day, period)
+		i, ok := byStart[start]
+		if !ok {
+			i = len(out)
+			byStart[start] = i
+			out = append(out, TransactionBucket{Start: start, End: end})
+		}
+		out[i].Transactions = append(out[i].Transactions, transaction)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Start.Before(out[j].Start)
+	})
+	return out
+}
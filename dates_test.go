@@ -0,0 +1,150 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func civilDay(t *testing.T, value string) CivilDate {
+	t.Helper()
+	d, err := ParseCivilDate(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestCivilDateArithmetic(t *testing.T) {
+	cases := []struct {
+		from   string
+		months int
+		want   string
+	}{
+		{"2023-01-31", 1, "2023-02-28"},
+		{"2024-01-31", 1, "2024-02-29"},
+		{"2023-03-31", -1, "2023-02-28"},
+		{"2023-12-15", 1, "2024-01-15"},
+		{"2023-05-31", 12, "2024-05-31"},
+	}
+	for _, c := range cases {
+		if got := civilDay(t, c.from).AddMonths(c.months).String(); got != c.want {
+			t.Errorf("%s + %d months = %s, want %s", c.from, c.months, got, c.want)
+		}
+	}
+	if got := civilDay(t, "2023-12-31").AddDays(1).String(); got != "2024-01-01" {
+		t.Errorf("AddDays %s", got)
+	}
+	if n := civilDay(t, "2023-03-01").DaysUntil(civilDay(t, "2023-02-01")); n != -28 {
+		t.Errorf("DaysUntil %d", n)
+	}
+	if !civilDay(t, "2023-02-01").Before(civilDay(t, "2023-02-02")) || civilDay(t, "2023-02-01").After(civilDay(t, "2023-02-01")) {
+		t.Error("Before/After")
+	}
+	if _, err := ParseCivilDate("02/01/2023"); err == nil {
+		t.Error("parsed a us date")
+	}
+}
+
+func TestCivilDateMarshalling(t *testing.T) {
+	var doc struct {
+		Date CivilDate `json:"date" bson:"date"`
+	}
+	doc.Date = civilDay(t, "2023-07-04")
+	raw, err := json.Marshal(doc)
+	if err != nil || string(raw) != `{"date":"2023-07-04"}` {
+		t.Fatalf("json %s %v", raw, err)
+	}
+	for _, empty := range []string{`{"date":""}`, `{"date":null}`} {
+		doc.Date = civilDay(t, "2023-07-04")
+		if err := json.Unmarshal([]byte(empty), &doc); err != nil || !doc.Date.IsZero() {
+			t.Errorf("%s: %v %v", empty, doc.Date, err)
+		}
+	}
+	doc.Date = civilDay(t, "2023-07-04")
+	raw, err = bson.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Date = CivilDate{}
+	if err := bson.Unmarshal(raw, &doc); err != nil || doc.Date.String() != "2023-07-04" {
+		t.Errorf("bson %v %v", doc.Date, err)
+	}
+	raw, _ = bson.Marshal(bson.M{"date": time.Date(2023, 7, 4, 23, 0, 0, 0, time.UTC)})
+	if err := bson.Unmarshal(raw, &doc); err != nil || doc.Date.String() != "2023-07-04" {
+		t.Errorf("bson datetime %v %v", doc.Date, err)
+	}
+}
+
+func TestEffectiveDate(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("no zoneinfo")
+	}
+	swipe := time.Date(2023, 3, 31, 20, 0, 0, 0, time.UTC) // already april 1st in tokyo
+	transaction := CowTransaction{
+		Date:               "2023-04-03",
+		AuthorizedDate:     "2023-03-30",
+		AuthorizedDatetime: swipe,
+	}
+	effective, exact := transaction.EffectiveTime(tokyo)
+	if !exact || !effective.Equal(swipe) || transaction.EffectiveDate(tokyo).String() != "2023-04-01" {
+		t.Errorf("authorized datetime: %v %v", effective, exact)
+	}
+	transaction.AuthorizedDatetime = time.Time{}
+	if _, exact := transaction.EffectiveTime(tokyo); exact || transaction.EffectiveDate(tokyo).String() != "2023-03-30" {
+		t.Errorf("authorized date %v", transaction.EffectiveDate(tokyo))
+	}
+	transaction.AuthorizedDate = ""
+	if transaction.EffectiveDate(nil).String() != "2023-04-03" {
+		t.Errorf("posting date %v", transaction.EffectiveDate(nil))
+	}
+	if !(CowTransaction{}).EffectiveDate(nil).IsZero() {
+		t.Error("no dates should be the zero date")
+	}
+}
+
+func TestBucketer(t *testing.T) {
+	cases := []struct {
+		bucketer   Bucketer
+		period     BucketPeriod
+		on         string
+		start, end string
+	}{
+		{Bucketer{}, BucketDay, "2023-05-10", "2023-05-10", "2023-05-11"},
+		{Bucketer{}, BucketWeek, "2023-05-10", "2023-05-07", "2023-05-14"}, // sunday weeks
+		{Bucketer{WeekStart: time.Monday}, BucketWeek, "2023-05-07", "2023-05-01", "2023-05-08"},
+		{Bucketer{}, BucketMonth, "2023-02-28", "2023-02-01", "2023-03-01"},
+		{Bucketer{BillingDay: 15}, BucketBillingCycle, "2023-05-10", "2023-04-15", "2023-05-15"},
+		{Bucketer{BillingDay: 15}, BucketBillingCycle, "2023-05-15", "2023-05-15", "2023-06-15"},
+		{Bucketer{BillingDay: 31}, BucketBillingCycle, "2023-02-28", "2023-02-28", "2023-03-31"},
+		{Bucketer{BillingDay: 31}, BucketBillingCycle, "2023-02-27", "2023-01-31", "2023-02-28"},
+	}
+	for _, c := range cases {
+		start, end := c.bucketer.Bucket(civilDay(t, c.on), c.period)
+		if start.String() != c.start || end.String() != c.end {
+			t.Errorf("%+v period %d on %s: [%s, %s), want [%s, %s)", c.bucketer, c.period, c.on, start, end, c.start, c.end)
+		}
+	}
+}
+
+func TestBucketerGroup(t *testing.T) {
+	transactions := []CowTransaction{
+		{TransactionID: "b", Date: "2023-06-02"},
+		{TransactionID: "a", Date: "2023-05-30"},
+		{TransactionID: "none"},
+		{TransactionID: "c", Date: "2023-06-20"},
+	}
+	buckets := Bucketer{}.Group(transactions, BucketMonth)
+	if len(buckets) != 2 {
+		t.Fatalf("got %+v", buckets)
+	}
+	if buckets[0].Start.String() != "2023-05-01" || len(buckets[0].Transactions) != 1 {
+		t.Errorf("may %+v", buckets[0])
+	}
+	if buckets[1].Start.String() != "2023-06-01" || len(buckets[1].Transactions) != 2 || buckets[1].Transactions[0].TransactionID != "b" {
+		t.Errorf("june %+v", buckets[1])
+	}
+}
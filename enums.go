@@ -0,0 +1,50 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// enumNames is what the values of one of our int enums are called in json, logs and the data
+// files.  Each enum keeps one of these and its String, Parse and json methods go through it
+type enumNames[E ~int] struct {
+	kind  string // for errors and values with no name, "source(7)"
+	names map[E]string
+}
+
+func (e enumNames[E]) name(value E) string {
+	if name, ok := e.names[value]; ok {
+		return name
+	}
+	return fmt.Sprintf("%s(%d)", e.kind, int(value))
+}
+
+// parse ignores case and surrounding space, unknown names come back as the zero value
+func (e enumNames[E]) parse(name string) (E, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for value, known := range e.names {
+		if known == name {
+			return value, nil
+		}
+	}
+	var zero E
+	return zero, fmt.Errorf("unknown %s %q", e.kind, name)
+}
+
+func (e enumNames[E]) marshalJSON(value E) ([]byte, error) {
+	return json.Marshal(e.name(value))
+}
+
+func (e enumNames[E]) unmarshalJSON(raw []byte, value *E) error {
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return fmt.Errorf("%s: %w", e.kind, err)
+	}
+	parsed, err := e.parse(name)
+	if err != nil {
+		return err
+	}
+	*value = parsed
+	return nil
+}
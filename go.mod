@@ -1,3 +1,5 @@
 module github.com/lpreimesberger/spacecow-common
 
 go 1.18
+
+require go.mongodb.org/mongo-driver v1.11.9
@@ -0,0 +1,221 @@
+package spacecow_common
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/i18n/*.csv
+var embeddedMessages embed.FS
+
+var defaultCatalog = mustLoadEmbeddedCatalog()
+
+var messageHeader = []string{"key", "text"}
+
+var languageCodes = enumNames[LanguageCode]{kind: "lang", names: map[LanguageCode]string{
+	En: "en",
+	Es: "es",
+}}
+
+var tipTypeKeys = map[CowTipsType]string{
+	CowTipsSmart:   "tip_type.smart",
+	CowTipsTax:     "tip_type.tax",
+	CowTipsOffer:   "tip_type.offer",
+	CowTipsUnusual: "tip_type.unusual",
+	CowTipsUnknown: "tip_type.unknown",
+}
+
+func (l LanguageCode) String() string {
+	return languageCodes.name(l)
+}
+
+// MarshalJSON writes the two letter code, "es"
+func (l LanguageCode) MarshalJSON() ([]byte, error) {
+	return languageCodes.marshalJSON(l)
+}
+
+// UnmarshalJSON reads a code the way ParseLanguageCode does
+func (l *LanguageCode) UnmarshalJSON(raw []byte) error {
+	var code string
+	if err := json.Unmarshal(raw, &code); err != nil {
+		return fmt.Errorf("lang: %w", err)
+	}
+	*l = ParseLanguageCode(code)
+	return nil
+}
+
+// ParseLanguageCode reads "es", "es-MX", "ES_us" and friends - anything unknown is English
+func ParseLanguageCode(code string) LanguageCode {
+	code = strings.TrimSpace(code)
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	if lang, err := languageCodes.parse(code); err == nil {
+		return lang
+	}
+	return En
+}
+
+// CategoryMessageKey is the catalog key for the name of one level of the category path
+func CategoryMessageKey(categoryID string) string {
+	return "category." + categoryID
+}
+
+// MessageCatalog holds translated text per language, English being the fallback for everything
+type MessageCatalog struct {
+	Versions map[LanguageCode]string
+	messages map[LanguageCode]map[string]string
+}
+
+// DefaultCatalog is the catalog embedded in this package
+func DefaultCatalog() *MessageCatalog {
+	return defaultCatalog
+}
+
+// NewMessageCatalog is an empty catalog, fill it with Load
+func NewMessageCatalog() *MessageCatalog {
+	return &MessageCatalog{Versions: map[LanguageCode]string{}, messages: map[LanguageCode]map[string]string{}}
+}
+
+// Load reads a key,text csv for one language, later loads replace earlier keys
+func (c *MessageCatalog) Load(lang LanguageCode, r io.Reader) error {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	reader, err := newDataFileReader(raw, messageHeader)
+	if err != nil {
+		return fmt.Errorf("messages %s %w", lang, err)
+	}
+	messages, ok := c.messages[lang]
+	if !ok {
+		messages = map[string]string{}
+		c.messages[lang] = messages
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		messages[strings.TrimSpace(record[0])] = record[1]
+	}
+	c.Versions[lang] = dataFileVersion(raw)
+	return nil
+}
+
+func mustLoadEmbeddedCatalog() *MessageCatalog {
+	c := NewMessageCatalog()
+	files, err := embeddedMessages.ReadDir("data/i18n")
+	if err != nil {
+		panic("embedded messages: " + err.Error())
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		lang := ParseLanguageCode(name)
+		if lang.String() != name {
+			panic("embedded messages: unknown language file " + file.Name())
+		}
+		f, err := embeddedMessages.Open("data/i18n/" + file.Name())
+		if err != nil {
+			panic("embedded messages: " + err.Error())
+		}
+		err = c.Load(lang, f)
+		_ = f.Close()
+		if err != nil {
+			panic("embedded messages: " + err.Error())
+		}
+	}
+	return c
+}
+
+// Message looks a key up in a language, then in English
+func (c *MessageCatalog) Message(lang LanguageCode, key string) (string, bool) {
+	if text, ok := c.messages[lang][key]; ok {
+		return text, true
+	}
+	text, ok := c.messages[En][key]
+	return text, ok
+}
+
+// Format looks a message up and fills in its {name} placeholders, unknown keys come back as the key
+func (c *MessageCatalog) Format(lang LanguageCode, key string, args map[string]string) string {
+	text, ok := c.Message(lang, key)
+	if !ok {
+		return key
+	}
+	for name, value := range args {
+		text = strings.ReplaceAll(text, "{"+name+"}", value)
+	}
+	return text
+}
+
+// CategoryName is the name of the last level of a category - the catalog first, then the taxonomy
+func (c *MessageCatalog) CategoryName(lang LanguageCode, categoryID string) string {
+	if text, ok := c.Message(lang, CategoryMessageKey(categoryID)); ok {
+		return text
+	}
+	if found, ok := DefaultTaxonomy().Lookup(categoryID); ok {
+		path := CategoryPath(found.DetailedDescription)
+		return path[len(path)-1]
+	}
+	return c.Format(lang, CategoryMessageKey("unknown"), nil)
+}
+
+// LocalizeTransactionMap translates Description and DetailedDescription level by level
+func (c *MessageCatalog) LocalizeTransactionMap(found TransactionMap, lang LanguageCode) TransactionMap {
+	if _, ok := DefaultTaxonomy().Lookup(found.ID); !ok {
+		unknown := c.CategoryName(lang, "unknown")
+		found.Description = unknown
+		found.DetailedDescription = unknown
+		return found
+	}
+	var levels []string
+	for _, ancestor := range DefaultTaxonomy().Ancestors(found.ID) {
+		levels = append(levels, c.CategoryName(lang, ancestor.ID))
+	}
+	levels = append(levels, c.CategoryName(lang, found.ID))
+	found.Description = levels[0]
+	found.DetailedDescription = strings.Join(levels, CategoryPathSeparator)
+	return found
+}
+
+// LocalizeTip renders a tip's Description from its MessageKey - tips without a key or with a key
+// the catalog doesn't know keep the Description they were built with.  {savings} is always available
+func (c *MessageCatalog) LocalizeTip(tip CowTips, lang LanguageCode) CowTips {
+	if tip.MessageKey == "" {
+		return tip
+	}
+	if _, ok := c.Message(lang, tip.MessageKey); !ok {
+		return tip
+	}
+	args := map[string]string{"savings": strconv.Itoa(tip.Savings)}
+	for name, value := range tip.MessageArgs {
+		args[name] = value
+	}
+	tip.Description = c.Format(lang, tip.MessageKey, args)
+	return tip
+}
+
+// TipTypeName is the display name for a kind of tip
+func (c *MessageCatalog) TipTypeName(lang LanguageCode, tipType CowTipsType) string {
+	return c.Format(lang, tipTypeKeys[tipType], nil)
+}
+
+// LocalizeTransactionMap translates a mapping with the embedded catalog
+func LocalizeTransactionMap(found TransactionMap, lang LanguageCode) TransactionMap {
+	return DefaultCatalog().LocalizeTransactionMap(found, lang)
+}
+
+// LocalizeTip renders a tip with the embedded catalog
+func LocalizeTip(tip CowTips, lang LanguageCode) CowTips {
+	return DefaultCatalog().LocalizeTip(tip, lang)
+}
@@ -0,0 +1,108 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseLanguageCode(t *testing.T) {
+	for in, want := range map[string]LanguageCode{"es": Es, "es-MX": Es, "ES_us": Es, "en": En, "fr": En, "": En} {
+		if got := ParseLanguageCode(in); got != want {
+			t.Errorf("%q: got %v", in, got)
+		}
+	}
+}
+
+func TestLanguageCodeJSON(t *testing.T) {
+	raw, err := json.Marshal(LanguageCode(Es))
+	if err != nil || string(raw) != `"es"` {
+		t.Fatalf("got %s %v", raw, err)
+	}
+	var lang LanguageCode
+	if err := json.Unmarshal([]byte(`"es-MX"`), &lang); err != nil || lang != Es {
+		t.Fatalf("got %v %v", lang, err)
+	}
+	if got := LanguageCode(9).String(); got != "lang(9)" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestLocalizeTransactionMap(t *testing.T) {
+	found := LocalizeTransactionMap(DetailedClassify(CowTransaction{CategoryID: "13001001"}), Es)
+	if found.Description != "comida y bebida" || found.DetailedDescription != "comida y bebida=>bar=>bar de vinos" {
+		t.Errorf("got %+v", found)
+	}
+	english := LocalizeTransactionMap(DetailedClassify(CowTransaction{CategoryID: "13001001"}), En)
+	if english.DetailedDescription != "food and drink=>bar=>wine bar" {
+		t.Errorf("got %+v", english)
+	}
+	if got := LocalizeTransactionMap(TransactionMap{ID: "nope"}, Es); got.Description != "desconocido" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestMessageFallsBackToEnglish(t *testing.T) {
+	c := NewMessageCatalog()
+	if err := c.Load(En, strings.NewReader("key,text\nhello,Hello {name}\nbye,Bye\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Load(Es, strings.NewReader("key,text\nhello,Hola {name}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Format(Es, "hello", map[string]string{"name": "Ana"}); got != "Hola Ana" {
+		t.Errorf("got %q", got)
+	}
+	if got := c.Format(Es, "bye", nil); got != "Bye" {
+		t.Errorf("got %q", got)
+	}
+	if got := c.Format(Es, "missing", nil); got != "missing" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestLocalizeTip(t *testing.T) {
+	c := NewMessageCatalog()
+	if err := c.Load(Es, strings.NewReader("key,text\ntip.fees,Pagaste {total} en comisiones y puedes ahorrar {savings}\n")); err != nil {
+		t.Fatal(err)
+	}
+	tip := c.LocalizeTip(CowTips{MessageKey: "tip.fees", MessageArgs: map[string]string{"total": "12.00"}, Savings: 40}, Es)
+	if tip.Description != "Pagaste 12.00 en comisiones y puedes ahorrar 40" {
+		t.Errorf("got %q", tip.Description)
+	}
+	for _, built := range []CowTips{{Description: "as built"}, {MessageKey: "tip.nope", Description: "as built"}} {
+		if untouched := c.LocalizeTip(built, Es); untouched.Description != "as built" {
+			t.Errorf("got %q", untouched.Description)
+		}
+	}
+}
+
+var placeholder = regexp.MustCompile(`\{[a-z_]+\}`)
+
+func placeholders(text string) []string {
+	found := placeholder.FindAllString(text, -1)
+	sort.Strings(found)
+	return found
+}
+
+// every english message needs a spanish one using the same placeholders
+func TestSpanishCatalogIsComplete(t *testing.T) {
+	c := DefaultCatalog()
+	for key, english := range c.messages[En] {
+		spanish, ok := c.messages[Es][key]
+		if !ok {
+			t.Errorf("%s has no spanish text", key)
+			continue
+		}
+		if strings.Join(placeholders(english), " ") != strings.Join(placeholders(spanish), " ") {
+			t.Errorf("%s: placeholders differ between %q and %q", key, english, spanish)
+		}
+	}
+	for _, entry := range DefaultTaxonomy().Entries() {
+		if _, ok := c.messages[Es][CategoryMessageKey(entry.ID)]; !ok {
+			t.Errorf("category %s has no spanish name", entry.ID)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package spacecow_common
+
+import "encoding/json"
+
+var shipTypeNames = enumNames[ShipTypes]{kind: "ship", names: map[ShipTypes]string{
+	ScoutShip:    "scout",
+	AberdeenShip: "aberdeen",
+	HerefordShip: "hereford",
+	LonghornShip: "longhorn",
+	WagyuShip:    "wagyu",
+}}
+
+var subscriptionLevelNames = enumNames[SubscriptionLevel]{kind: "level", names: map[SubscriptionLevel]string{
+	Trial:           "trial",
+	Skim:            "skim",
+	LowFat:          "lowfat",
+	Whole:           "whole",
+	Hyperpasturized: "hyperpasturized",
+}}
+
+func (ship ShipTypes) String() string {
+	return shipTypeNames.name(ship)
+}
+
+// ParseShipType reads a ship name written by String
+func ParseShipType(name string) (ShipTypes, error) {
+	return shipTypeNames.parse(name)
+}
+
+func (level SubscriptionLevel) String() string {
+	return subscriptionLevelNames.name(level)
+}
+
+// ParseSubscriptionLevel reads a level name written by String
+func ParseSubscriptionLevel(name string) (SubscriptionLevel, error) {
+	return subscriptionLevelNames.parse(name)
+}
+
+// MarshalJSON writes the ship name.  Only json changes, bson still stores the number
+func (ship ShipTypes) MarshalJSON() ([]byte, error) {
+	return shipTypeNames.marshalJSON(ship)
+}
+
+// UnmarshalJSON reads a ship name, or the plain number older documents have
+func (ship *ShipTypes) UnmarshalJSON(raw []byte) error {
+	var number int
+	if err := json.Unmarshal(raw, &number); err == nil {
+		*ship = ShipTypes(number)
+		return nil
+	}
+	return shipTypeNames.unmarshalJSON(raw, ship)
+}
@@ -0,0 +1,290 @@
+package spacecow_common
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// processor and bank boilerplate in front of the merchant, stripped repeatedly
+var descriptorPrefix = regexp.MustCompile(`(?i)^(?:` +
+	`(?:SQ|SQU|TST|PAYPAL|PP|PY|SP|GOOGLE|GGL|IC|DD|EB|FS|CKO|BT|PAR)\s*\*\s*|` +
+	`POS (?:DEBIT|PURCHASE)\s+|` +
+	`DEBIT (?:CARD )?PURCHASE\s+(?:-\s*)?|` +
+	`CHECKCARD\s+\d{4}\s+|` +
+	`PURCHASE AUTHORIZED ON \d{1,2}/\d{1,2}\s+|` +
+	`RECURRING (?:PAYMENT|DEBIT)\s+(?:-\s*)?|` +
+	`ACH (?:DEBIT|WITHDRAWAL)\s+(?:-\s*)?)`)
+
+var (
+	descriptorDomain = regexp.MustCompile(`(?i)^(?:www\.)?([a-z0-9-]+)\.(?:com|net|org|io|tv|co|us)(?:/.*)?$`)
+	descriptorMask   = regexp.MustCompile(`(?i)^(?:[x*]{3,}\d*|[x*]{2,}\d{2,})$`)
+	descriptorDate   = regexp.MustCompile(`^\d{1,2}/\d{1,2}(?:/\d{2,4})?$`)
+	descriptorPhone  = regexp.MustCompile(`^(?:\d{3}[-.])?\d{3}[-.]\d{4}$`)
+	descriptorStore  = regexp.MustCompile(`^(?:#\S*|NO\.?\d+)$`)
+)
+
+var usStates = map[string]bool{}
+
+func init() {
+	for _, code := range strings.Fields("AL AK AZ AR CA CO CT DE DC FL GA HI ID IL IN IA KS KY LA ME MD MA MI MN MS MO " +
+		"MT NE NV NH NJ NM NY NC ND OH OK OR PA PR RI SC SD TN TX UT VT VA WA WV WI WY") {
+		usStates[code] = true
+	}
+}
+
+// first words of two word city names, so "SAN JOSE" goes as a whole
+var cityPrefixes = map[string]bool{
+	"SAN": true, "SANTA": true, "LOS": true, "LAS": true, "NEW": true, "ST": true, "SAINT": true, "FORT": true,
+	"FT": true, "EL": true, "LA": true, "PALO": true, "SALT": true, "NORTH": true, "SOUTH": true, "EAST": true,
+	"WEST": true, "LONG": true, "DALY": true, "MOUNTAIN": true, "REDWOOD": true, "CITY": true, "BATON": true,
+}
+
+// words that only label the store number or card mask after them
+var descriptorLabels = map[string]bool{
+	"STORE": true, "STR": true, "NO": true, "UNIT": true, "LOC": true, "CARD": true, "ENDING": true, "#": true,
+}
+
+// merchants that show up under several spellings, the longest match wins.  Words after the
+// alias are kept ("amazon prime video") unless swallow is set - lyft and doordash put the ride
+// or the restaurant there
+var merchantAliases = []struct {
+	words   string
+	key     string
+	display string
+	swallow bool
+}{
+	{"amzn prime", "amazon prime", "Amazon Prime", false},
+	{"amazon prime", "amazon prime", "Amazon Prime", false},
+	{"amzn mktp", "amazon", "Amazon", true},
+	{"amzn digital", "amazon", "Amazon", true},
+	{"amzn", "amazon", "Amazon", false},
+	{"amazon com", "amazon", "Amazon", false},
+	{"amazon mktplace", "amazon", "Amazon", true},
+	{"amazon marketplace", "amazon", "Amazon", true},
+	{"wm supercenter", "walmart", "Walmart", true},
+	{"wal mart", "walmart", "Walmart", false},
+	{"walmart com", "walmart", "Walmart", false},
+	{"apple com bill", "apple", "Apple", true},
+	{"nflx", "netflix", "Netflix", false},
+	{"lyft", "lyft", "Lyft", true},
+	{"doordash", "doordash", "DoorDash", true},
+}
+
+// NormalizedMerchant is a cleaned up merchant descriptor
+type NormalizedMerchant struct {
+	Raw         string `json:"raw" bson:"raw"`
+	Key         string `json:"key" bson:"key"` // lower case words, what grouping should use
+	DisplayName string `json:"display_name" bson:"displayName"`
+}
+
+type descriptorToken struct {
+	text string
+	wide bool // came after a run of spaces - card networks pad the name to a fixed width
+}
+
+func descriptorTokens(text string) []descriptorToken {
+	var out []descriptorToken
+	spaces := 0
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			out = append(out, descriptorToken{text: text[start:end], wide: spaces > 1 && len(out) > 0})
+			start = -1
+			spaces = 0
+		}
+	}
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			flush(i)
+			spaces++
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(text))
+	return out
+}
+
+// stripReferenceCode deals with the "*" most processors put between parts of the descriptor
+func stripReferenceCode(text string) string {
+	left, right, found := strings.Cut(text, "*")
+	if !found {
+		return text
+	}
+	left, right = strings.TrimSpace(left), strings.TrimSpace(strings.ReplaceAll(right, "*", " "))
+	first := strings.Fields(right + " ")
+	switch {
+	case left == "":
+		return right
+	case len(first) == 0 || strings.ContainsAny(first[0], "0123456789"):
+		return left // "AMZN Mktp US*2K4L"
+	case len(left) <= 3 && !strings.Contains(left, " "):
+		return right // a processor code we don't know yet
+	}
+	return left + " " + right
+}
+
+func isReferenceCode(token string) bool {
+	hasDigit := strings.ContainsAny(token, "0123456789")
+	hasLetter := strings.IndexFunc(token, unicode.IsLetter) >= 0
+	return len(token) >= 4 && hasDigit && hasLetter
+}
+
+func isLetters(token string) bool {
+	return strings.IndexFunc(token, func(r rune) bool { return !unicode.IsLetter(r) }) < 0
+}
+
+func isAllDigits(token string) bool {
+	return token != "" && strings.Trim(token, "0123456789-") == ""
+}
+
+// titleWord turns "BLUE" into "Blue" and "7-ELEVEN" into "7-Eleven"
+func titleWord(word string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range strings.ToLower(word) {
+		if upper && unicode.IsLetter(r) {
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+			continue
+		}
+		b.WriteRune(r)
+		if !unicode.IsLetter(r) && r != '\'' && r != '’' {
+			upper = true
+		}
+	}
+	return b.String()
+}
+
+// NormalizeMerchant cleans a raw Name or OriginalDescription like "SQ *BLUE BOTTLE 0423 OAKLAND CA"
+// down to the merchant, "blue bottle" / "Blue Bottle".  Processor prefixes, store numbers, card
+// masks, dates, phone numbers, reference codes and the trailing city and state all go
+func NormalizeMerchant(raw string) NormalizedMerchant {
+	text := strings.TrimSpace(raw)
+	for i := 0; i < 3; i++ {
+		stripped := descriptorPrefix.ReplaceAllString(text, "")
+		if stripped == text {
+			break
+		}
+		text = strings.TrimSpace(stripped)
+	}
+	text = stripReferenceCode(text)
+	tokens := descriptorTokens(text)
+	drop := make([]bool, len(tokens))
+	lastNoise := -1
+	for i := range tokens {
+		token := strings.Trim(tokens[i].text, ",;:")
+		if match := descriptorDomain.FindStringSubmatch(token); match != nil {
+			token = match[1]
+		}
+		tokens[i].text = token
+		upper := strings.ToUpper(token)
+		switch {
+		case token == "" || strings.Trim(token, "-.") == "":
+			drop[i] = true
+		case descriptorMask.MatchString(token), descriptorDate.MatchString(token), descriptorPhone.MatchString(token),
+			descriptorStore.MatchString(upper):
+			drop[i] = true
+			lastNoise = i
+		case i > 0 && (isAllDigits(token) && len(token) >= 3 || isReferenceCode(token)):
+			drop[i] = true
+			lastNoise = i
+		}
+	}
+	kept := func() []int {
+		var out []int
+		for i := range tokens {
+			if !drop[i] {
+				out = append(out, i)
+			}
+		}
+		return out
+	}
+	// trailing country and state, then whatever city sits in front of the state
+	if k := kept(); len(k) >= 2 {
+		last := k[len(k)-1]
+		if upper := strings.ToUpper(tokens[last].text); upper == "US" || upper == "USA" {
+			drop[last] = true
+			k = k[:len(k)-1]
+		}
+		if last = k[len(k)-1]; len(k) >= 2 && usStates[strings.ToUpper(tokens[last].text)] {
+			drop[last] = true
+			k = k[:len(k)-1]
+			wide := -1
+			for _, i := range k[1:] {
+				if tokens[i].wide {
+					wide = i
+				}
+			}
+			switch {
+			case lastNoise > k[0] && lastNoise < last:
+				for i := lastNoise + 1; i < last; i++ {
+					drop[i] = true
+				}
+			case wide > 0:
+				for i := wide; i < last; i++ {
+					drop[i] = true
+				}
+			case len(k) >= 3:
+				drop[k[len(k)-1]] = true
+				if previous := k[len(k)-2]; len(k) >= 3 && cityPrefixes[strings.ToUpper(tokens[previous].text)] {
+					drop[previous] = true
+				}
+			}
+		}
+	}
+	// a country code straight after a phone number or reference, "4029357733 LU"
+	if k := kept(); len(k) >= 2 && lastNoise >= 0 && k[len(k)-1] == lastNoise+1 {
+		if code := tokens[lastNoise+1].text; len(code) == 2 && strings.ToUpper(code) == code && isLetters(code) {
+			drop[lastNoise+1] = true
+		}
+	}
+	for i := len(tokens) - 2; i >= 0; i-- {
+		if !drop[i] && drop[i+1] && descriptorLabels[strings.ToUpper(tokens[i].text)] && i > 0 {
+			drop[i] = true
+		}
+	}
+	var words []string
+	for _, i := range kept() {
+		words = append(words, tokens[i].text)
+	}
+	cleaned := strings.Join(words, " ")
+	key := normalizeMerchantText(cleaned)
+	if key == "" {
+		key = normalizeMerchantText(raw)
+		cleaned = strings.TrimSpace(raw)
+	}
+	out := NormalizedMerchant{Raw: raw, Key: key}
+	best := -1
+	for i, alias := range merchantAliases {
+		if (key == alias.words || strings.HasPrefix(key, alias.words+" ")) &&
+			(best < 0 || len(alias.words) > len(merchantAliases[best].words)) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		alias := merchantAliases[best]
+		out.Key, out.DisplayName = alias.key, alias.display
+		if rest := strings.Fields(strings.TrimPrefix(key, alias.words)); len(rest) > 0 && !alias.swallow {
+			for i := range rest {
+				rest[i] = titleWord(rest[i])
+			}
+			out.Key += " " + strings.ToLower(strings.Join(rest, " "))
+			out.DisplayName += " " + strings.Join(rest, " ")
+		}
+		return out
+	}
+	if strings.IndexFunc(cleaned, unicode.IsLower) >= 0 {
+		out.DisplayName = cleaned // someone already cased it
+		return out
+	}
+	words = strings.Fields(cleaned)
+	for i := range words {
+		words[i] = titleWord(words[i])
+	}
+	out.DisplayName = strings.Join(words, " ")
+	return out
+}
@@ -0,0 +1,45 @@
+package spacecow_common
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+// TestMerchantDescriptorCorpus runs NormalizeMerchant over the descriptors we have collected
+// from real statements
+func TestMerchantDescriptorCorpus(t *testing.T) {
+	raw, err := os.ReadFile("testdata/merchant_descriptors.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := newDataFileReader(raw, []string{"raw", "key", "display_name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		descriptor, key, display := record[0], record[1], record[2]
+		t.Run(descriptor, func(t *testing.T) {
+			got := NormalizeMerchant(descriptor)
+			if got.Key != key || got.DisplayName != display {
+				t.Errorf("got %q / %q, want %q / %q", got.Key, got.DisplayName, key, display)
+			}
+		})
+	}
+}
+
+func TestNormalizeMerchantEmpty(t *testing.T) {
+	for _, descriptor := range []string{"", "   ", "\t\n"} {
+		if got := NormalizeMerchant(descriptor); got.Key != "" || got.DisplayName != "" {
+			t.Errorf("%q: %+v", descriptor, got)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package spacecow_common
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/merchants.csv
+var embeddedMerchants []byte
+
+//go:embed data/merchant_keywords.csv
+var embeddedMerchantKeywords []byte
+
+var defaultMerchantDictionary = mustParseMerchantDictionary(embeddedMerchants, embeddedMerchantKeywords)
+
+var merchantHeader = []string{"merchant", "category_id"}
+var merchantKeywordHeader = []string{"keyword", "category_id", "confidence"}
+
+// how sure we are for each kind of dictionary hit
+const (
+	MerchantNameConfidence        = 0.95 // plaid's merchant name is in the dictionary
+	MerchantDescriptionConfidence = 0.8  // a dictionary merchant shows up in the name or description
+)
+
+// MerchantKeyword is a fallback rule - a word or phrase that hints at a category
+type MerchantKeyword struct {
+	Keyword    string
+	CategoryID string
+	Confidence float64
+}
+
+// MerchantDictionary is the curated merchant list plus keyword rules used when plaid gives us
+// no usable category id
+type MerchantDictionary struct {
+	Version         string
+	KeywordsVersion string
+	merchants       map[string]string
+	keywords        []MerchantKeyword
+}
+
+// MerchantMatch is a merchant dictionary classification
+type MerchantMatch struct {
+	Map        TransactionMap `json:"map" bson:"map"`
+	Confidence float64        `json:"confidence" bson:"confidence"`
+	Matched    string         `json:"matched" bson:"matched"` // the merchant or keyword that hit
+	ByKeyword  bool           `json:"by_keyword" bson:"byKeyword"`
+}
+
+// DefaultMerchantDictionary is the dictionary embedded in this package
+func DefaultMerchantDictionary() *MerchantDictionary {
+	return defaultMerchantDictionary
+}
+
+// ParseMerchantDictionary reads a merchant,category_id csv and a keyword,category_id,confidence csv
+func ParseMerchantDictionary(merchants io.Reader, keywords io.Reader) (*MerchantDictionary, error) {
+	rawMerchants, err := io.ReadAll(merchants)
+	if err != nil {
+		return nil, err
+	}
+	rawKeywords, err := io.ReadAll(keywords)
+	if err != nil {
+		return nil, err
+	}
+	d := &MerchantDictionary{
+		Version:         dataFileVersion(rawMerchants),
+		KeywordsVersion: dataFileVersion(rawKeywords),
+		merchants:       map[string]string{},
+	}
+	reader, err := newDataFileReader(rawMerchants, merchantHeader)
+	if err != nil {
+		return nil, fmt.Errorf("merchants %w", err)
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := normalizeMerchantText(record[0])
+		if _, dup := d.merchants[name]; dup {
+			return nil, fmt.Errorf("merchants: duplicate merchant %s", name)
+		}
+		d.merchants[name] = strings.TrimSpace(record[1])
+	}
+	reader, err = newDataFileReader(rawKeywords, merchantKeywordHeader)
+	if err != nil {
+		return nil, fmt.Errorf("merchant keywords %w", err)
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		confidence, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if err != nil || confidence < 0 || confidence > 1 {
+			return nil, fmt.Errorf("merchant keywords %s: bad confidence %q", record[0], record[2])
+		}
+		d.keywords = append(d.keywords, MerchantKeyword{
+			Keyword:    normalizeMerchantText(record[0]),
+			CategoryID: strings.TrimSpace(record[1]),
+			Confidence: confidence,
+		})
+	}
+	return d, nil
+}
+
+func mustParseMerchantDictionary(merchants []byte, keywords []byte) *MerchantDictionary {
+	d, err := ParseMerchantDictionary(bytes.NewReader(merchants), bytes.NewReader(keywords))
+	if err != nil {
+		panic("embedded merchant dictionary: " + err.Error())
+	}
+	return d
+}
+
+// normalizeMerchantText lower cases and reduces text to single space separated words -
+// apostrophes and ampersands are dropped so "McDonald's" and "AT&T" stay one word
+func normalizeMerchantText(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == '\'' || r == '’' || r == '&':
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// containsWords is true when phrase appears in text on word boundaries, both normalized
+func containsWords(text string, phrase string) bool {
+	return phrase != "" && strings.Contains(" "+text+" ", " "+phrase+" ")
+}
+
+// Match classifies on merchant name first, then the cleaned up name and original description,
+// then dictionary merchants anywhere inside them, then keyword rules
+func (d *MerchantDictionary) Match(transaction CowTransaction) (MerchantMatch, bool) {
+	build := func(id string, confidence float64, matched string, byKeyword bool) (MerchantMatch, bool) {
+		found, ok := DefaultTaxonomy().Lookup(id)
+		if !ok {
+			return MerchantMatch{}, false
+		}
+		return MerchantMatch{
+			Map:        withAmountSign(found, transaction.Amount),
+			Confidence: confidence,
+			Matched:    matched,
+			ByKeyword:  byKeyword,
+		}, true
+	}
+	merchant := normalizeMerchantText(transaction.MerchantName)
+	if id, ok := d.merchants[merchant]; ok {
+		return build(id, MerchantNameConfidence, merchant, false)
+	}
+	for _, descriptor := range []string{transaction.Name, transaction.OriginalDescription} {
+		if key := NormalizeMerchant(descriptor).Key; key != "" {
+			if id, ok := d.merchants[key]; ok {
+				return build(id, MerchantDescriptionConfidence, key, false)
+			}
+		}
+	}
+	text := normalizeMerchantText(transaction.Name + " " + transaction.OriginalDescription)
+	best := ""
+	for name := range d.merchants {
+		longer := len(name) > len(best) || (len(name) == len(best) && name < best)
+		if longer && containsWords(text, name) {
+			best = name
+		}
+	}
+	if best != "" {
+		return build(d.merchants[best], MerchantDescriptionConfidence, best, false)
+	}
+	text = normalizeMerchantText(transaction.MerchantName + " " + transaction.Name + " " + transaction.OriginalDescription)
+	var hit *MerchantKeyword
+	for i, keyword := range d.keywords {
+		if !containsWords(text, keyword.Keyword) {
+			continue
+		}
+		if hit == nil || keyword.Confidence > hit.Confidence ||
+			(keyword.Confidence == hit.Confidence && len(keyword.Keyword) > len(hit.Keyword)) {
+			hit = &d.keywords[i]
+		}
+	}
+	if hit != nil {
+		return build(hit.CategoryID, hit.Confidence, hit.Keyword, true)
+	}
+	return MerchantMatch{}, false
+}
+
+// ClassifyMerchant runs the embedded merchant dictionary over a transaction
+func ClassifyMerchant(transaction CowTransaction) (MerchantMatch, bool) {
+	return DefaultMerchantDictionary().Match(transaction)
+}
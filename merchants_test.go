@@ -0,0 +1,76 @@
+package spacecow_common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMerchantDictionaryMatch(t *testing.T) {
+	for _, c := range []struct {
+		name        string
+		transaction CowTransaction
+		want        string
+		confidence  float64
+		byKeyword   bool
+	}{
+		{"merchant name", CowTransaction{MerchantName: "Netflix", Amount: 15}, "18061000", MerchantNameConfidence, false},
+		{"descriptor", CowTransaction{Name: "NETFLIX.COM 866-579-7172 CA", Amount: 15}, "18061000", MerchantDescriptionConfidence, false},
+		{"longest merchant inside", CowTransaction{Name: "PAYMENT TO AMAZON PRIME THANK YOU", Amount: 15}, "18061000", MerchantDescriptionConfidence, false},
+		{"keyword", CowTransaction{Name: "MONTHLY OVERDRAFT FEE", Amount: 35}, "10001000", 0.9, true},
+	} {
+		got, ok := ClassifyMerchant(c.transaction)
+		if !ok || got.Map.ID != c.want || got.Confidence != c.confidence || got.ByKeyword != c.byKeyword {
+			t.Errorf("%s: got %+v %v", c.name, got, ok)
+		}
+	}
+	if got, ok := ClassifyMerchant(CowTransaction{Name: "ZXQV 1234"}); ok {
+		t.Errorf("nothing should match, got %+v", got)
+	}
+}
+
+func TestClassifyFallsBackToMerchants(t *testing.T) {
+	explained := ClassifyExplain(CowTransaction{Name: "NETFLIX.COM", Amount: 15})
+	if explained.Source != SourceMerchant || explained.Map.ID != "18061000" {
+		t.Errorf("unknown category id: %s", explained)
+	}
+	explained = ClassifyExplain(CowTransaction{Name: "NETFLIX.COM", CategoryID: "13000000", Amount: 15})
+	if explained.Source != SourceCategoryID {
+		t.Errorf("a known category id should win: %s", explained)
+	}
+}
+
+func TestNormalizeMerchantText(t *testing.T) {
+	for in, want := range map[string]string{
+		"McDonald's #123": "mcdonalds 123",
+		"AT&T  Wireless":  "att wireless",
+		"  ":              "",
+	} {
+		if got := normalizeMerchantText(in); got != want {
+			t.Errorf("%q: got %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseMerchantDictionaryRejectsBadFiles(t *testing.T) {
+	keywords := "keyword,category_id,confidence\nfee,10000000,0.5\n"
+	if _, err := ParseMerchantDictionary(strings.NewReader("merchant,category_id\na,1\nA,2\n"), strings.NewReader(keywords)); err == nil {
+		t.Error("duplicate merchant accepted")
+	}
+	if _, err := ParseMerchantDictionary(strings.NewReader("merchant,category_id\n"), strings.NewReader("keyword,category_id,confidence\nfee,1,2\n")); err == nil {
+		t.Error("confidence above 1 accepted")
+	}
+}
+
+func TestEveryMerchantMapsIntoTheTaxonomy(t *testing.T) {
+	d := DefaultMerchantDictionary()
+	for merchant, id := range d.merchants {
+		if _, ok := DefaultTaxonomy().Lookup(id); !ok {
+			t.Errorf("%s maps to unknown category %s", merchant, id)
+		}
+	}
+	for _, keyword := range d.keywords {
+		if _, ok := DefaultTaxonomy().Lookup(keyword.CategoryID); !ok {
+			t.Errorf("%s maps to unknown category %s", keyword.Keyword, keyword.CategoryID)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TextModel is a multinomial naive Bayes classifier over the words in a transaction's
+// merchant name, name and original description.  Classes are taxonomy category ids
+type TextModel struct {
+	Version    string                    `json:"version" bson:"version"`
+	Trained    time.Time                 `json:"trained" bson:"trained"`
+	Documents  int                       `json:"documents" bson:"documents"`
+	ClassDocs  map[string]int            `json:"class_docs" bson:"classDocs"`
+	WordCounts map[string]map[string]int `json:"word_counts" bson:"wordCounts"` // class -> word -> count
+	ClassWords map[string]int            `json:"class_words" bson:"classWords"`
+	Vocabulary map[string]int            `json:"vocabulary" bson:"vocabulary"`
+}
+
+// ClassProbability is one class and how likely the model thinks it is
+type ClassProbability struct {
+	CategoryID  string  `json:"category_id" bson:"categoryId"`
+	Probability float64 `json:"probability" bson:"probability"`
+}
+
+// LabeledTransaction is a training example
+type LabeledTransaction struct {
+	Transaction CowTransaction
+	CategoryID  string
+}
+
+// ModelEvaluation compares model predictions against reference labels
+type ModelEvaluation struct {
+	Total     int                       `json:"total"`
+	Correct   int                       `json:"correct"`
+	Accuracy  float64                   `json:"accuracy"`
+	Confusion map[string]map[string]int `json:"confusion"` // actual -> predicted -> count
+}
+
+// NewTextModel is an empty, untrained model
+func NewTextModel() *TextModel {
+	return &TextModel{
+		ClassDocs:  map[string]int{},
+		WordCounts: map[string]map[string]int{},
+		ClassWords: map[string]int{},
+		Vocabulary: map[string]int{},
+	}
+}
+
+// transactionTokens is the bag of words the model sees for a transaction - digits only
+// tokens are dropped, they are store numbers and reference codes
+func transactionTokens(transaction CowTransaction) []string {
+	text := normalizeMerchantText(transaction.MerchantName + " " + transaction.Name + " " + transaction.OriginalDescription)
+	var tokens []string
+	for _, word := range strings.Fields(text) {
+		if strings.Trim(word, "0123456789") == "" {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// ensureMaps fills in maps a zero model or a null field in a stored document left nil
+func (m *TextModel) ensureMaps() {
+	if m.ClassDocs == nil {
+		m.ClassDocs = map[string]int{}
+	}
+	if m.WordCounts == nil {
+		m.WordCounts = map[string]map[string]int{}
+	}
+	if m.ClassWords == nil {
+		m.ClassWords = map[string]int{}
+	}
+	if m.Vocabulary == nil {
+		m.Vocabulary = map[string]int{}
+	}
+}
+
+// Train adds labeled examples to the model - it can be called repeatedly as history grows.
+// A zero TextModel is ready to train
+func (m *TextModel) Train(examples []LabeledTransaction) {
+	m.ensureMaps()
+	for _, example := range examples {
+		if example.CategoryID == "" {
+			continue
+		}
+		tokens := transactionTokens(example.Transaction)
+		if len(tokens) == 0 {
+			continue
+		}
+		m.Documents++
+		m.ClassDocs[example.CategoryID]++
+		counts, ok := m.WordCounts[example.CategoryID]
+		if !ok {
+			counts = map[string]int{}
+			m.WordCounts[example.CategoryID] = counts
+		}
+		for _, token := range tokens {
+			counts[token]++
+			m.ClassWords[example.CategoryID]++
+			m.Vocabulary[token]++
+		}
+	}
+	m.Trained = time.Now().UTC()
+}
+
+// labelFromTable labels stored transactions with their category id, skipping anything the
+// table calls unknown - the model could never predict those
+func labelFromTable(history []CowTransaction) []LabeledTransaction {
+	examples := make([]LabeledTransaction, 0, len(history))
+	for _, transaction := range history {
+		if _, known := DefaultTaxonomy().Lookup(transaction.CategoryID); !known {
+			continue
+		}
+		examples = append(examples, LabeledTransaction{Transaction: transaction, CategoryID: transaction.CategoryID})
+	}
+	return examples
+}
+
+// TrainFromHistory labels stored transactions with DetailedClassify and trains on them,
+// skipping anything the table calls unknown
+func (m *TextModel) TrainFromHistory(history []CowTransaction) {
+	m.Train(labelFromTable(history))
+}
+
+// Predict returns every class the model knows, most likely first.  Probabilities sum to 1
+// and nothing comes back for an untrained model or a transaction with no usable words.  It
+// only reads the maps so nil ones are fine and trained models can be shared between goroutines
+func (m *TextModel) Predict(transaction CowTransaction) []ClassProbability {
+	tokens := transactionTokens(transaction)
+	if m.Documents == 0 || len(tokens) == 0 {
+		return nil
+	}
+	vocabulary := float64(len(m.Vocabulary))
+	out := make([]ClassProbability, 0, len(m.ClassDocs))
+	best := math.Inf(-1)
+	for class, docs := range m.ClassDocs {
+		score := math.Log(float64(docs) / float64(m.Documents))
+		denominator := float64(m.ClassWords[class]) + vocabulary
+		for _, token := range tokens {
+			// laplace smoothing so unseen words don't zero a class out
+			score += math.Log((float64(m.WordCounts[class][token]) + 1) / denominator)
+		}
+		if score > best {
+			best = score
+		}
+		out = append(out, ClassProbability{CategoryID: class, Probability: score})
+	}
+	total := 0.0
+	for i := range out {
+		out[i].Probability = math.Exp(out[i].Probability - best)
+		total += out[i].Probability
+	}
+	for i := range out {
+		out[i].Probability /= total
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Probability != out[j].Probability {
+			return out[i].Probability > out[j].Probability
+		}
+		return out[i].CategoryID < out[j].CategoryID
+	})
+	return out
+}
+
+// Best is the single most likely class
+func (m *TextModel) Best(transaction CowTransaction) (ClassProbability, bool) {
+	predictions := m.Predict(transaction)
+	if len(predictions) == 0 {
+		return ClassProbability{}, false
+	}
+	return predictions[0], true
+}
+
+// Evaluate predicts every example and compares with its label
+func (m *TextModel) Evaluate(examples []LabeledTransaction) ModelEvaluation {
+	result := ModelEvaluation{Confusion: map[string]map[string]int{}}
+	for _, example := range examples {
+		predicted := ""
+		if best, ok := m.Best(example.Transaction); ok {
+			predicted = best.CategoryID
+		}
+		result.Total++
+		if predicted == example.CategoryID {
+			result.Correct++
+		}
+		row, ok := result.Confusion[example.CategoryID]
+		if !ok {
+			row = map[string]int{}
+			result.Confusion[example.CategoryID] = row
+		}
+		row[predicted]++
+	}
+	if result.Total > 0 {
+		result.Accuracy = float64(result.Correct) / float64(result.Total)
+	}
+	return result
+}
+
+// EvaluateAgainstTable scores the model against DetailedClassify labels of stored transactions,
+// leaving out the ones TrainFromHistory would skip
+func (m *TextModel) EvaluateAgainstTable(history []CowTransaction) ModelEvaluation {
+	return m.Evaluate(labelFromTable(history))
+}
+
+// Save writes the model as json
+func (m *TextModel) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(m)
+}
+
+// LoadTextModel reads a model written by Save
+func LoadTextModel(r io.Reader) (*TextModel, error) {
+	m := NewTextModel()
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,79 @@
+package spacecow_common
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func modelHistory() []CowTransaction {
+	var history []CowTransaction
+	for i := 0; i < 5; i++ {
+		history = append(history,
+			CowTransaction{Name: "BLUE BOTTLE COFFEE", CategoryID: "13005043", Amount: 5},
+			CowTransaction{Name: "SHELL OIL 5512", CategoryID: "22009000", Amount: 40},
+		)
+	}
+	return history
+}
+
+func TestTextModelPredicts(t *testing.T) {
+	var m TextModel // a zero model has to train without panicking
+	m.TrainFromHistory(modelHistory())
+	best, ok := m.Best(CowTransaction{Name: "COFFEE SHOP"})
+	if !ok || best.CategoryID != "13005043" {
+		t.Fatalf("got %+v %v", best, ok)
+	}
+	total := 0.0
+	for _, prediction := range m.Predict(CowTransaction{Name: "SHELL"}) {
+		total += prediction.Probability
+	}
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("probabilities sum to %v", total)
+	}
+	if _, ok := m.Best(CowTransaction{Name: "1234"}); ok {
+		t.Error("digits only should not predict")
+	}
+}
+
+func TestTextModelNullMaps(t *testing.T) {
+	m, err := LoadTextModel(bytes.NewReader([]byte(`{"documents":0,"class_docs":null,"word_counts":null,"class_words":null,"vocabulary":null}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Best(CowTransaction{Name: "COFFEE"}); ok {
+		t.Error("untrained model predicted")
+	}
+	m.TrainFromHistory(modelHistory())
+	if m.Documents != 10 {
+		t.Errorf("documents %d", m.Documents)
+	}
+}
+
+func TestTextModelSaveLoad(t *testing.T) {
+	m := NewTextModel()
+	m.TrainFromHistory(modelHistory())
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadTextModel(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m.Predict(CowTransaction{Name: "BLUE COFFEE"}))
+	got, _ := json.Marshal(loaded.Predict(CowTransaction{Name: "BLUE COFFEE"}))
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestEvaluateAgainstTableSkipsUnknown(t *testing.T) {
+	m := NewTextModel()
+	m.TrainFromHistory(modelHistory())
+	history := append(modelHistory(), CowTransaction{Name: "BLUE BOTTLE COFFEE", CategoryID: "not a category", Amount: 5})
+	result := m.EvaluateAgainstTable(history)
+	if result.Total != 10 || result.Accuracy != 1 {
+		t.Errorf("got %+v", result)
+	}
+}
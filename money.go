@@ -0,0 +1,392 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RoundingMode is how Money drops digits it can't keep
+type RoundingMode int
+
+const (
+	RoundHalfEven RoundingMode = iota // bankers rounding - the default, no drift over many sums
+	RoundHalfUp                       // halves go away from zero
+	RoundDown                         // toward zero
+	RoundUp                           // away from zero
+)
+
+// ErrCurrencyMismatch is returned when mixing amounts in different currencies
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Money is an exact amount in the minor unit of its currency (cents for USD).  It marshals to
+// json and bson as {amount, currency} so the currency survives the trip.  A plain number, the
+// way our float64 amounts have always been stored, is still read as major units of whatever
+// currency is already set on the value
+type Money struct {
+	Minor    int64
+	Currency string
+}
+
+func pow10(n int) int64 {
+	out := int64(1)
+	for i := 0; i < n; i++ {
+		out *= 10
+	}
+	return out
+}
+
+// NewMoney is an amount already in minor units
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: strings.ToUpper(currency)}
+}
+
+// roundRat rounds an exact rational to an integer
+func roundRat(r *big.Rat, mode RoundingMode) int64 {
+	quo, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return quo.Int64()
+	}
+	negative := r.Sign() < 0
+	away := func() int64 {
+		if negative {
+			return quo.Int64() - 1
+		}
+		return quo.Int64() + 1
+	}
+	switch mode {
+	case RoundDown:
+		return quo.Int64()
+	case RoundUp:
+		return away()
+	}
+	twice := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+	switch twice.Cmp(r.Denom()) {
+	case -1:
+		return quo.Int64()
+	case 1:
+		return away()
+	}
+	if mode == RoundHalfUp || quo.Bit(0) == 1 {
+		return away()
+	}
+	return quo.Int64()
+}
+
+// ParseMoney reads a decimal string like "-12.345" in major units, rounding anything past the
+// currency's minor unit
+func ParseMoney(decimal string, currency string, mode RoundingMode) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(decimal))
+	if !ok {
+		return Money{}, fmt.Errorf("money: can't parse %q", decimal)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(pow10(MinorUnits(currency))))
+	return NewMoney(roundRat(r, mode), currency), nil
+}
+
+// MoneyFromFloat converts one of our stored float64 amounts.  The float is read as the shortest
+// decimal that round trips, so 0.1+0.2 style noise doesn't leak into the cents
+func MoneyFromFloat(amount float64, currency string, mode RoundingMode) Money {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return NewMoney(0, currency)
+	}
+	m, _ := ParseMoney(strconv.FormatFloat(amount, 'f', -1, 64), currency, mode)
+	return m
+}
+
+// Float64 is the amount in major units for the float64 fields and charts
+func (m Money) Float64() float64 {
+	f, _ := strconv.ParseFloat(m.Decimal(), 64)
+	return f
+}
+
+// Decimal is the exact amount in major units, "-12.34"
+func (m Money) Decimal() string {
+	digits := MinorUnits(m.Currency)
+	sign := ""
+	minor := m.Minor
+	if minor < 0 {
+		sign = "-"
+	}
+	abs := new(big.Int).Abs(big.NewInt(minor)).String()
+	if digits == 0 {
+		return sign + abs
+	}
+	if len(abs) <= digits {
+		abs = strings.Repeat("0", digits-len(abs)+1) + abs
+	}
+	return sign + abs[:len(abs)-digits] + "." + abs[len(abs)-digits:]
+}
+
+// String is "12.34 USD"
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.Currency
+}
+
+// IsZero is true for no money at all
+func (m Money) IsZero() bool {
+	return m.Minor == 0
+}
+
+// IsNegative is true for money coming in on plaid's sign convention
+func (m Money) IsNegative() bool {
+	return m.Minor < 0
+}
+
+// Neg flips the sign
+func (m Money) Neg() Money {
+	return Money{Minor: -m.Minor, Currency: m.Currency}
+}
+
+// Abs drops the sign
+func (m Money) Abs() Money {
+	if m.Minor < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+func (m Money) sameCurrency(other Money) (string, error) {
+	switch {
+	case m.Currency == other.Currency:
+		return m.Currency, nil
+	case m.IsZero() && m.Currency == "":
+		return other.Currency, nil
+	case other.IsZero() && other.Currency == "":
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+}
+
+// Add sums two amounts of the same currency - a zero Money{} adds to anything
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.sameCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Minor: m.Minor + other.Minor, Currency: currency}, nil
+}
+
+// Sub takes other away from m
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Add(other.Neg())
+}
+
+// Cmp is -1, 0 or 1 like strings.Compare
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Minor < other.Minor:
+		return -1, nil
+	case m.Minor > other.Minor:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Equal is exact equality including the currency - what duplicate detection should use
+func (m Money) Equal(other Money) bool {
+	return m.Minor == other.Minor && m.Currency == other.Currency
+}
+
+// Mul multiplies by a whole number
+func (m Money) Mul(n int64) Money {
+	return Money{Minor: m.Minor * n, Currency: m.Currency}
+}
+
+// Scale multiplies by a factor such as a rate or percentage, rounding the result
+func (m Money) Scale(factor float64, mode RoundingMode) Money {
+	r := new(big.Rat).SetInt64(m.Minor)
+	f, ok := new(big.Rat).SetString(strconv.FormatFloat(factor, 'f', -1, 64))
+	if !ok {
+		return Money{Currency: m.Currency}
+	}
+	return Money{Minor: roundRat(r.Mul(r, f), mode), Currency: m.Currency}
+}
+
+// Div divides by a whole number, rounding the result
+func (m Money) Div(n int64, mode RoundingMode) Money {
+	if n == 0 {
+		return Money{Currency: m.Currency}
+	}
+	return Money{Minor: roundRat(big.NewRat(m.Minor, n), mode), Currency: m.Currency}
+}
+
+// Allocate splits into n parts that add back up exactly, the leftover minor units going to
+// the first parts
+func (m Money) Allocate(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+	out := make([]Money, n)
+	each := m.Minor / int64(n)
+	left := m.Minor % int64(n)
+	for i := range out {
+		out[i] = Money{Minor: each, Currency: m.Currency}
+		switch {
+		case left > 0:
+			out[i].Minor++
+			left--
+		case left < 0:
+			out[i].Minor--
+			left++
+		}
+	}
+	return out
+}
+
+// SumMoney adds a list of same currency amounts
+func SumMoney(amounts ...Money) (Money, error) {
+	var total Money
+	for _, amount := range amounts {
+		var err error
+		if total, err = total.Add(amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// moneyJSON is how Money looks in json - amount is an exact decimal number of major units
+type moneyJSON struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+// MarshalJSON writes {"amount": 12.34, "currency": "USD"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: json.Number(m.Decimal()), Currency: m.Currency})
+}
+
+// UnmarshalJSON reads what MarshalJSON writes, or a bare number or numeric string in major units
+// of the currency already set on m
+func (m *Money) UnmarshalJSON(raw []byte) error {
+	decoded := moneyJSON{Currency: m.Currency}
+	if trimmed := strings.TrimSpace(string(raw)); strings.HasPrefix(trimmed, "{") {
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return fmt.Errorf("money: %w", err)
+		}
+	} else if err := json.Unmarshal(raw, &decoded.Amount); err != nil {
+		return fmt.Errorf("money: %w", err)
+	}
+	if decoded.Amount == "" {
+		decoded.Amount = "0"
+	}
+	parsed, err := ParseMoney(decoded.Amount.String(), decoded.Currency, RoundHalfEven)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// MarshalBSONValue stores {amount: decimal128, currency} - decimal128 keeps the amount exact
+// and still sorts and compares as a number in queries
+func (m Money) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	amount, err := primitive.ParseDecimal128(m.Decimal())
+	if err != nil {
+		return 0, nil, fmt.Errorf("money: %w", err)
+	}
+	return bson.MarshalValue(bson.D{{Key: "amount", Value: amount}, {Key: "currency", Value: m.Currency}})
+}
+
+// bsonDecimal is any bson number as a decimal string
+func bsonDecimal(value bson.RawValue) (string, error) {
+	switch value.Type {
+	case bsontype.Double:
+		return strconv.FormatFloat(value.Double(), 'f', -1, 64), nil
+	case bsontype.Int32:
+		return strconv.FormatInt(int64(value.Int32()), 10), nil
+	case bsontype.Int64:
+		return strconv.FormatInt(value.Int64(), 10), nil
+	case bsontype.Decimal128:
+		return value.Decimal128().String(), nil
+	case bsontype.String:
+		return value.StringValue(), nil
+	case bsontype.Null, bsontype.Undefined:
+		return "0", nil
+	}
+	return "", fmt.Errorf("money: can't read bson %s", value.Type)
+}
+
+// UnmarshalBSONValue reads what MarshalBSONValue writes, or any bson number in major units of
+// the currency already set on m
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value := bson.RawValue{Type: t, Value: data}
+	currency := m.Currency
+	if t == bsontype.EmbeddedDocument {
+		document := value.Document()
+		if stored, ok := document.Lookup("currency").StringValueOK(); ok {
+			currency = stored
+		}
+		value = document.Lookup("amount")
+		if value.Type == 0 {
+			value = bson.RawValue{Type: bsontype.Null}
+		}
+	}
+	decimal, err := bsonDecimal(value)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseMoney(decimal, currency, RoundHalfEven)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// CurrencyCode is the iso code, or plaid's unofficial code when there is no iso one
+func (transaction CowTransaction) CurrencyCode() string {
+	if transaction.IsoCurrencyCode != "" {
+		return transaction.IsoCurrencyCode
+	}
+	return transaction.UnofficialCurrencyCode
+}
+
+// Money is the transaction amount in its own currency
+func (transaction CowTransaction) Money() Money {
+	return MoneyFromFloat(transaction.Amount, transaction.CurrencyCode(), RoundHalfEven)
+}
+
+// SetMoney stores an exact amount back in the float64 field, switching the currency code if needed
+func (transaction *CowTransaction) SetMoney(amount Money) {
+	transaction.Amount = amount.Float64()
+	if amount.Currency != transaction.CurrencyCode() {
+		transaction.IsoCurrencyCode = amount.Currency
+		transaction.UnofficialCurrencyCode = ""
+	}
+}
+
+// Money is the subscription amount - the struct doesn't carry a currency so the caller says
+func (subscription PossibleSubscriptions) Money(currency string) Money {
+	return MoneyFromFloat(subscription.Amount, currency, RoundHalfEven)
+}
+
+// SetMoney stores an exact amount back in the float64 field
+func (subscription *PossibleSubscriptions) SetMoney(amount Money) {
+	subscription.Amount = amount.Float64()
+}
+
+// Money is the category total - the struct doesn't carry a currency so the caller says
+func (category Categories) Money(currency string) Money {
+	return MoneyFromFloat(category.Total, currency, RoundHalfEven)
+}
+
+// SetMoney stores an exact amount back in the float64 field
+func (category *Categories) SetMoney(amount Money) {
+	category.Total = amount.Float64()
+}
@@ -0,0 +1,115 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type moneyDocument struct {
+	Total Money `json:"total" bson:"total"`
+}
+
+func TestMoneyJSONRoundTrip(t *testing.T) {
+	for _, amount := range []Money{NewMoney(1234, "JPY"), NewMoney(-12345, "KWD"), NewMoney(199, "USD"), NewMoney(0, "")} {
+		raw, err := json.Marshal(moneyDocument{amount})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back moneyDocument
+		if err := json.Unmarshal(raw, &back); err != nil {
+			t.Fatal(err)
+		}
+		if back.Total != amount {
+			t.Errorf("%s: %s came back as %+v", amount, raw, back.Total)
+		}
+	}
+	raw, _ := json.Marshal(NewMoney(1234, "JPY"))
+	if string(raw) != `{"amount":1234,"currency":"JPY"}` {
+		t.Errorf("got %s", raw)
+	}
+	raw, _ = json.Marshal(NewMoney(-12345, "KWD"))
+	if string(raw) != `{"amount":-12.345,"currency":"KWD"}` {
+		t.Errorf("got %s", raw)
+	}
+}
+
+func TestMoneyBSONRoundTrip(t *testing.T) {
+	for _, amount := range []Money{NewMoney(1234, "JPY"), NewMoney(-12345, "KWD"), NewMoney(199, "USD")} {
+		raw, err := bson.Marshal(moneyDocument{amount})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back moneyDocument
+		if err := bson.Unmarshal(raw, &back); err != nil {
+			t.Fatal(err)
+		}
+		if back.Total != amount {
+			t.Errorf("%s came back as %+v", amount, back.Total)
+		}
+	}
+}
+
+// documents written before Money existed hold a plain number in major units
+func TestMoneyReadsBareNumbers(t *testing.T) {
+	back := moneyDocument{Total: NewMoney(0, "KWD")}
+	if err := json.Unmarshal([]byte(`{"total":1.5}`), &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Total != NewMoney(1500, "KWD") {
+		t.Errorf("json got %+v", back.Total)
+	}
+	raw, _ := bson.Marshal(bson.M{"total": 12.34})
+	back = moneyDocument{Total: NewMoney(0, "USD")}
+	if err := bson.Unmarshal(raw, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Total != NewMoney(1234, "USD") {
+		t.Errorf("bson got %+v", back.Total)
+	}
+}
+
+func TestMoneyRounding(t *testing.T) {
+	for _, c := range []struct {
+		decimal string
+		mode    RoundingMode
+		want    int64
+	}{
+		{"0.125", RoundHalfEven, 12},
+		{"0.135", RoundHalfEven, 14},
+		{"0.125", RoundHalfUp, 13},
+		{"-0.125", RoundHalfUp, -13},
+		{"0.129", RoundDown, 12},
+		{"0.121", RoundUp, 13},
+	} {
+		got, err := ParseMoney(c.decimal, "USD", c.mode)
+		if err != nil || got.Minor != c.want {
+			t.Errorf("%s mode %d: got %d %v", c.decimal, c.mode, got.Minor, err)
+		}
+	}
+	if got := MoneyFromFloat(0.1+0.2, "USD", RoundHalfEven); got.Minor != 30 {
+		t.Errorf("float noise leaked: %d", got.Minor)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	total, err := SumMoney(NewMoney(100, "USD"), NewMoney(-30, "USD"))
+	if err != nil || total != NewMoney(70, "USD") {
+		t.Fatalf("got %v %v", total, err)
+	}
+	if _, err := NewMoney(1, "USD").Add(NewMoney(1, "JPY")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("mixed currencies: %v", err)
+	}
+	parts := NewMoney(100, "USD").Allocate(3)
+	if len(parts) != 3 || parts[0].Minor+parts[1].Minor+parts[2].Minor != 100 {
+		t.Errorf("allocate %v", parts)
+	}
+	if got := NewMoney(-5, "USD").Decimal(); got != "-0.05" {
+		t.Errorf("decimal %s", got)
+	}
+	if got := NewMoney(5, "JPY").Decimal(); got != "5" {
+		t.Errorf("decimal %s", got)
+	}
+}
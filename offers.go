@@ -0,0 +1,354 @@
+package spacecow_common
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed data/offers.csv
+var embeddedOffers []byte
+
+var defaultOfferCatalog = mustParseOfferCatalog(embeddedOffers)
+
+var offerHeader = []string{"id", "title", "merchants", "categories", "min_spend", "cashback_percent", "flat_savings",
+	"ships", "levels", "expires", "action_url"}
+
+// Offer is a product or deal we can suggest as a CowTipsOffer
+type Offer struct {
+	ID              string              `json:"id" bson:"_id"`
+	Title           string              `json:"title" bson:"title"`
+	Merchants       []string            `json:"merchants" bson:"merchants"`      // normalized merchant names
+	CategoryIDs     []string            `json:"category_ids" bson:"categoryIds"` // each covers everything under it
+	MinSpend        float64             `json:"min_spend" bson:"minSpend"`       // matching spend in the window before we show it
+	CashbackPercent float64             `json:"cashback_percent" bson:"cashbackPercent"`
+	FlatSavings     float64             `json:"flat_savings" bson:"flatSavings"` // a year
+	Ships           []ShipTypes         `json:"ships" bson:"ships"`              // empty for everyone
+	Levels          []SubscriptionLevel `json:"levels" bson:"levels"`            // empty for everyone
+	Expires         CivilDate           `json:"expires" bson:"expires"`          // last day to show it, zero for never
+	ActionURL       string              `json:"action_url" bson:"actionUrl"`
+}
+
+// OfferCatalog is the list of offers
+type OfferCatalog struct {
+	Version string
+	offers  []Offer
+}
+
+// DefaultOfferCatalog is the catalog embedded in this package
+func DefaultOfferCatalog() *OfferCatalog {
+	return defaultOfferCatalog
+}
+
+// NewOfferCatalog wraps offers loaded from somewhere else, the database say
+func NewOfferCatalog(version string, offers []Offer) *OfferCatalog {
+	return &OfferCatalog{Version: version, offers: append([]Offer(nil), offers...)}
+}
+
+func splitOfferList(value string) []string {
+	var out []string
+	for _, item := range strings.Split(value, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func parseOfferRecord(record []string) (Offer, error) {
+	offer := Offer{
+		ID:          strings.TrimSpace(record[0]),
+		Title:       strings.TrimSpace(record[1]),
+		CategoryIDs: splitOfferList(record[3]),
+		ActionURL:   strings.TrimSpace(record[10]),
+	}
+	for _, merchant := range splitOfferList(record[2]) {
+		offer.Merchants = append(offer.Merchants, normalizeMerchantText(merchant))
+	}
+	for _, id := range offer.CategoryIDs {
+		if _, ok := DefaultTaxonomy().Lookup(id); !ok {
+			return Offer{}, fmt.Errorf("unknown category %s", id)
+		}
+	}
+	numbers := []*float64{&offer.MinSpend, &offer.CashbackPercent, &offer.FlatSavings}
+	for i, target := range numbers {
+		value := strings.TrimSpace(record[4+i])
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 {
+			return Offer{}, fmt.Errorf("%s: bad number %q", offerHeader[4+i], value)
+		}
+		*target = parsed
+	}
+	for _, name := range splitOfferList(record[7]) {
+		ship, err := ParseShipType(name)
+		if err != nil {
+			return Offer{}, err
+		}
+		offer.Ships = append(offer.Ships, ship)
+	}
+	for _, name := range splitOfferList(record[8]) {
+		level, err := ParseSubscriptionLevel(name)
+		if err != nil {
+			return Offer{}, err
+		}
+		offer.Levels = append(offer.Levels, level)
+	}
+	if value := strings.TrimSpace(record[9]); value != "" {
+		expires, err := ParseCivilDate(value)
+		if err != nil {
+			return Offer{}, err
+		}
+		offer.Expires = expires
+	}
+	return offer, nil
+}
+
+// ParseOfferCatalog reads an offers csv, see data/offers.csv for the columns
+func ParseOfferCatalog(r io.Reader) (*OfferCatalog, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := newDataFileReader(raw, offerHeader)
+	if err != nil {
+		return nil, fmt.Errorf("offers %w", err)
+	}
+	catalog := &OfferCatalog{Version: dataFileVersion(raw)}
+	seen := map[string]bool{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		offer, err := parseOfferRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("offers %s: %w", record[0], err)
+		}
+		if offer.ID == "" || seen[offer.ID] {
+			return nil, fmt.Errorf("offers: missing or duplicate id %q", offer.ID)
+		}
+		seen[offer.ID] = true
+		catalog.offers = append(catalog.offers, offer)
+	}
+	return catalog, nil
+}
+
+// OpenOfferCatalog loads a catalog from disk
+func OpenOfferCatalog(path string) (*OfferCatalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseOfferCatalog(f)
+}
+
+func mustParseOfferCatalog(raw []byte) *OfferCatalog {
+	catalog, err := ParseOfferCatalog(bytes.NewReader(raw))
+	if err != nil {
+		panic("embedded offers: " + err.Error())
+	}
+	return catalog
+}
+
+// Offers lists the catalog in file order
+func (c *OfferCatalog) Offers() []Offer {
+	return append([]Offer(nil), c.offers...)
+}
+
+// OfferUser is who the offers are for
+type OfferUser struct {
+	UID   string
+	Ship  ShipTypes
+	Level SubscriptionLevel
+}
+
+// Eligible is true when the offer is open to the user on a day
+func (offer Offer) Eligible(user OfferUser, on CivilDate) bool {
+	if !offer.Expires.IsZero() && on.After(offer.Expires) {
+		return false
+	}
+	shipOK := len(offer.Ships) == 0
+	for _, ship := range offer.Ships {
+		shipOK = shipOK || ship == user.Ship
+	}
+	levelOK := len(offer.Levels) == 0
+	for _, level := range offer.Levels {
+		levelOK = levelOK || level == user.Level
+	}
+	return shipOK && levelOK
+}
+
+// targetsCategory is true when a category id is under one of the offer's categories
+func (offer Offer) targetsCategory(categoryID string) bool {
+	for _, target := range offer.CategoryIDs {
+		if DefaultTaxonomy().IsUnder(categoryID, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Targets is true when a transaction counts toward the offer
+func (offer Offer) Targets(transaction CowTransaction) bool {
+	if len(offer.Merchants) == 0 && len(offer.CategoryIDs) == 0 {
+		return true
+	}
+	key := merchantKey(transaction)
+	for _, merchant := range offer.Merchants {
+		if containsWords(key, merchant) {
+			return true
+		}
+	}
+	return offer.targetsCategory(transactionCategoryID(transaction))
+}
+
+// OfferMatch is an offer scored for a user
+type OfferMatch struct {
+	Offer   Offer   `json:"offer" bson:"offer"`
+	Spend   float64 `json:"spend" bson:"spend"`     // matching spend in the window
+	Savings int     `json:"savings" bson:"savings"` // estimated, a year
+	Matched int     `json:"matched" bson:"matched"` // transactions behind Spend, 0 when it came from Categories
+}
+
+// OfferMatcher scores offers against a user's recent spending.  An empty matcher uses the
+// embedded catalog over the last 90 UTC days, in dollars like the catalog's own amounts
+type OfferMatcher struct {
+	Catalog    *OfferCatalog  // DefaultOfferCatalog
+	WindowDays int            // days of history counted (90) - Categories should cover the same
+	Currency   string         // the catalog's amounts and the Categories totals are in this (USD)
+	Location   *time.Location // user's zone for dates (UTC)
+}
+
+func (m OfferMatcher) withDefaults() OfferMatcher {
+	if m.Catalog == nil {
+		m.Catalog = DefaultOfferCatalog()
+	}
+	if m.WindowDays <= 0 {
+		m.WindowDays = 90
+	}
+	if m.Currency == "" {
+		m.Currency = "USD"
+	}
+	if m.Location == nil {
+		m.Location = time.UTC
+	}
+	return m
+}
+
+// categorySpend is the offer's spend from Categories totals, for users whose transactions we
+// no longer have
+func (offer Offer) categorySpend(categories []Categories, currency string) Money {
+	total := NewMoney(0, currency)
+	if len(offer.CategoryIDs) == 0 {
+		return total
+	}
+	for _, category := range categories {
+		found, ok := DefaultTaxonomy().LookupPath(category.FlatType)
+		if !ok {
+			found, ok = DefaultTaxonomy().Lookup(category.FlatType)
+		}
+		if ok && category.Total > 0 && offer.targetsCategory(found.ID) {
+			total, _ = total.Add(category.Money(currency))
+		}
+	}
+	return total
+}
+
+// Match scores every eligible offer against the spending in the window ending at asOf, best
+// savings first.  Offers below their minimum spend are left out, and so is spending in other
+// currencies than the matcher's - transactions without a currency count as in it
+func (m OfferMatcher) Match(user OfferUser, transactions []CowTransaction, categories []Categories, asOf CivilDate) []OfferMatch {
+	m = m.withDefaults()
+	from := asOf.AddDays(-m.WindowDays)
+	var recent []CowTransaction
+	for _, transaction := range transactions {
+		day := transaction.EffectiveDate(m.Location)
+		if transaction.Pending || transaction.Amount <= 0 || day.Before(from) || day.After(asOf) {
+			continue
+		}
+		if currency := transaction.CurrencyCode(); currency != "" && !strings.EqualFold(currency, m.Currency) {
+			continue
+		}
+		if user.UID == "" || transaction.UID == user.UID {
+			recent = append(recent, transaction)
+		}
+	}
+	var out []OfferMatch
+	for _, offer := range m.Catalog.offers {
+		if !offer.Eligible(user, asOf) {
+			continue
+		}
+		match := OfferMatch{Offer: offer}
+		spend := NewMoney(0, m.Currency)
+		for _, transaction := range recent {
+			if offer.Targets(transaction) {
+				spend, _ = spend.Add(MoneyFromFloat(transaction.Amount, m.Currency, RoundHalfEven))
+				match.Matched++
+			}
+		}
+		if match.Matched == 0 {
+			spend = offer.categorySpend(categories, m.Currency)
+		}
+		match.Spend = spend.Float64()
+		if match.Spend < offer.MinSpend {
+			continue
+		}
+		yearly := match.Spend * 365 / float64(m.WindowDays)
+		match.Savings = int(yearly*offer.CashbackPercent/100 + offer.FlatSavings)
+		if match.Savings <= 0 {
+			continue
+		}
+		out = append(out, match)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Savings != out[j].Savings {
+			return out[i].Savings > out[j].Savings
+		}
+		return out[i].Offer.ID < out[j].Offer.ID
+	})
+	return out
+}
+
+// Tip turns the match into a CowTipsOffer tip with an English Description
+func (match OfferMatch) Tip() CowTips {
+	return DefaultCatalog().LocalizeTip(CowTips{
+		Type:       CowTipsOffer,
+		ActionURL:  match.Offer.ActionURL,
+		Savings:    match.Savings,
+		MessageKey: "tip.offer",
+		MessageArgs: map[string]string{
+			"title": match.Offer.Title,
+			"spend": formatAmount(match.Spend),
+		},
+		Rule: "offers",
+		Key:  "offers:" + match.Offer.ID,
+	}, En)
+}
+
+// Tips are the matches as ranked CowTipsOffer tips
+func (m OfferMatcher) Tips(user OfferUser, transactions []CowTransaction, categories []Categories, asOf CivilDate) []CowTips {
+	var out []CowTips
+	for _, match := range m.Match(user, transactions, categories, asOf) {
+		out = append(out, match.Tip())
+	}
+	return out
+}
+
+// MatchOffers runs a default OfferMatcher
+func MatchOffers(user OfferUser, transactions []CowTransaction, categories []Categories, asOf CivilDate) []OfferMatch {
+	return OfferMatcher{}.Match(user, transactions, categories, asOf)
+}
@@ -0,0 +1,114 @@
+package spacecow_common
+
+import (
+	"strings"
+	"testing"
+)
+
+func groceryRun(id string, date string, amount float64) CowTransaction {
+	return CowTransaction{TransactionID: id, UID: "u1", Name: "SAFEWAY #1234", CategoryID: "19047000", Amount: amount, Date: date}
+}
+
+func matchIDs(matches []OfferMatch) string {
+	var ids []string
+	for _, match := range matches {
+		ids = append(ids, match.Offer.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestOfferMatch(t *testing.T) {
+	transactions := []CowTransaction{
+		groceryRun("a", "2023-04-01", 50.1),
+		groceryRun("b", "2023-05-01", 50.2),
+		groceryRun("c", "2023-06-01", 50.3),
+		groceryRun("old", "2023-01-01", 500), // outside the 90 days
+		{UID: "u1", Name: "VERIZON WIRELESS", CategoryID: "18063000", Amount: 90, Date: "2023-06-10"},
+	}
+	matches := OfferMatcher{}.Match(OfferUser{UID: "u1"}, transactions, nil, civilDay(t, "2023-06-15"))
+	if matchIDs(matches) != "phone-plan,grocery-cashback" {
+		t.Fatalf("matches %s", matchIDs(matches))
+	}
+	grocery := matches[1]
+	if grocery.Spend != 150.6 || grocery.Matched != 3 || grocery.Savings != 36 {
+		t.Errorf("grocery %+v", grocery)
+	}
+	if tip := grocery.Tip(); tip.Type != CowTipsOffer || tip.Key != "offers:grocery-cashback" || !strings.Contains(tip.Description, "150.60") {
+		t.Errorf("tip %+v", tip)
+	}
+}
+
+func TestOfferMatchSkipsOtherCurrencies(t *testing.T) {
+	transactions := []CowTransaction{
+		groceryRun("a", "2023-05-01", 100),
+		groceryRun("b", "2023-05-02", 100),
+	}
+	transactions[1].IsoCurrencyCode = "EUR"
+	if matches := (OfferMatcher{}).Match(OfferUser{}, transactions, nil, civilDay(t, "2023-06-15")); len(matches) != 0 {
+		t.Errorf("euro spend counted toward %s", matchIDs(matches))
+	}
+	// the one without a currency counts in whatever the matcher works in
+	matches := OfferMatcher{Currency: "eur"}.Match(OfferUser{}, transactions, nil, civilDay(t, "2023-06-15"))
+	if matchIDs(matches) != "grocery-cashback" || matches[0].Spend != 200 {
+		t.Errorf("matches %+v", matches)
+	}
+}
+
+func TestOfferMatchFromCategories(t *testing.T) {
+	categories := []Categories{
+		{UID: "u1", FlatType: "food and drink=>restaurants", Total: 600},
+		{UID: "u1", FlatType: "19047000", Total: 100},
+	}
+	matches := OfferMatcher{}.Match(OfferUser{UID: "u1"}, nil, categories, civilDay(t, "2023-06-15"))
+	if matchIDs(matches) != "dining-cashback" || matches[0].Spend != 600 || matches[0].Matched != 0 {
+		t.Errorf("matches %+v", matches)
+	}
+}
+
+func TestOfferEligible(t *testing.T) {
+	offers := map[string]Offer{}
+	for _, offer := range DefaultOfferCatalog().Offers() {
+		offers[offer.ID] = offer
+	}
+	today := civilDay(t, "2023-06-15")
+	if offers["high-yield-savings"].Eligible(OfferUser{Ship: ScoutShip}, today) {
+		t.Error("scouts get the savings account")
+	}
+	if !offers["high-yield-savings"].Eligible(OfferUser{Ship: HerefordShip}, today) {
+		t.Error("herefords don't get the savings account")
+	}
+	if offers["gym-discount"].Eligible(OfferUser{Level: Trial}, today) || !offers["gym-discount"].Eligible(OfferUser{Level: Whole}, today) {
+		t.Error("gym discount levels")
+	}
+	expiring := Offer{Expires: today}
+	if !expiring.Eligible(OfferUser{}, today) || expiring.Eligible(OfferUser{}, today.AddDays(1)) {
+		t.Error("expiry is the last day shown")
+	}
+}
+
+func TestParseOfferCatalog(t *testing.T) {
+	header := strings.Join(offerHeader, ",") + "\n"
+	catalog, err := ParseOfferCatalog(strings.NewReader("# version: 3\n" + header +
+		"x,X,Acme Co|Other,19047000,10,1.5,,wagyu,whole,2024-01-31,/x\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offer := catalog.Offers()[0]
+	if catalog.Version != "3" || strings.Join(offer.Merchants, "|") != "acme co|other" || offer.CashbackPercent != 1.5 ||
+		offer.Ships[0] != WagyuShip || offer.Levels[0] != Whole || offer.Expires.String() != "2024-01-31" {
+		t.Errorf("offer %+v", offer)
+	}
+	bad := []string{
+		"x,X,,99999999,,,,,,,\n",
+		"x,X,,,-5,,,,,,\n",
+		"x,X,,,,,,battleship,,,\n",
+		"x,X,,,,,,,,next week,\n",
+		",X,,,,,,,,,\n",
+		"x,X,,,,,,,,,\nx,Y,,,,,,,,,\n",
+	}
+	for _, rows := range bad {
+		if _, err := ParseOfferCatalog(strings.NewReader(header + rows)); err == nil {
+			t.Errorf("accepted %q", rows)
+		}
+	}
+}
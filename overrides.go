@@ -0,0 +1,224 @@
+package spacecow_common
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OverrideStage is when an override rule runs relative to the base taxonomy
+type OverrideStage int
+
+const (
+	// OverrideBefore rules see the raw plaid fields and skip every other classifier
+	OverrideBefore = iota
+	// OverrideAfter rules patch whatever the taxonomy decided
+	OverrideAfter
+)
+
+// OverrideRule is a user correction - every non-empty Match field has to match, then the Set
+// fields are applied.  Empty Set fields leave the classification alone
+type OverrideRule struct {
+	ID       string        `json:"id" bson:"_id"`
+	Stage    OverrideStage `json:"stage" bson:"stage"`
+	Priority int           `json:"priority" bson:"priority"` // higher runs first
+	Added    time.Time     `json:"added" bson:"added"`
+	// matchers
+	MatchMerchantName string   `json:"match_merchant_name" bson:"matchMerchantName"` // case insensitive exact
+	MatchNamePattern  string   `json:"match_name_pattern" bson:"matchNamePattern"`   // regex against Name and OriginalDescription
+	MatchAccountID    string   `json:"match_account_id" bson:"matchAccountId"`
+	MatchCategoryID   string   `json:"match_category_id" bson:"matchCategoryId"` // plaid id before, classified id after
+	MatchMinAmount    *float64 `json:"match_min_amount" bson:"matchMinAmount"`   // inclusive
+	MatchMaxAmount    *float64 `json:"match_max_amount" bson:"matchMaxAmount"`   // inclusive
+	// outcome
+	SetCategoryID       string       `json:"set_category_id" bson:"setCategoryId"`
+	SetTransactionType  *XactionType `json:"set_transaction_type" bson:"setTransactionType"`
+	SetPhysicalLocation *bool        `json:"set_physical_location" bson:"setPhysicalLocation"`
+}
+
+// OverrideRuleSet is every correction a user has made, stored as one document per UID
+type OverrideRuleSet struct {
+	UID      string         `json:"uid" bson:"_id"`
+	Rules    []OverrideRule `json:"rules" bson:"rules"`
+	Modified time.Time      `json:"modified" bson:"modified"`
+	mu       sync.Mutex
+	compiled *compiledOverrides
+}
+
+// compiledOverrides is the rules sorted per stage with their patterns compiled, built on first
+// use so sets decoded straight out of mongo work too.  rules is a deep copy of what it was built
+// from, an edit to the set's Rules makes it stale
+type compiledOverrides struct {
+	rules    []OverrideRule
+	stages   map[OverrideStage][]OverrideRule
+	patterns map[string]*regexp.Regexp
+}
+
+// clone copies a rule along with what its pointer fields point at
+func (rule OverrideRule) clone() OverrideRule {
+	rule.MatchMinAmount = clonePointer(rule.MatchMinAmount)
+	rule.MatchMaxAmount = clonePointer(rule.MatchMaxAmount)
+	rule.SetTransactionType = clonePointer(rule.SetTransactionType)
+	rule.SetPhysicalLocation = clonePointer(rule.SetPhysicalLocation)
+	return rule
+}
+
+// same compares two rules by value, pointer fields included
+func (rule OverrideRule) same(other OverrideRule) bool {
+	if !samePointee(rule.MatchMinAmount, other.MatchMinAmount) || !samePointee(rule.MatchMaxAmount, other.MatchMaxAmount) ||
+		!samePointee(rule.SetTransactionType, other.SetTransactionType) || !samePointee(rule.SetPhysicalLocation, other.SetPhysicalLocation) {
+		return false
+	}
+	rule.MatchMinAmount, rule.MatchMaxAmount, rule.SetTransactionType, rule.SetPhysicalLocation = nil, nil, nil, nil
+	other.MatchMinAmount, other.MatchMaxAmount, other.SetTransactionType, other.SetPhysicalLocation = nil, nil, nil, nil
+	return rule == other
+}
+
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func samePointee[T comparable](a *T, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// current is true while rules are still what was compiled
+func (compiled *compiledOverrides) current(rules []OverrideRule) bool {
+	if len(rules) != len(compiled.rules) {
+		return false
+	}
+	for i, rule := range rules {
+		if !rule.same(compiled.rules[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks every rule has a usable pattern, a known target category and does something
+func (set *OverrideRuleSet) Validate() error {
+	for _, rule := range set.Rules {
+		if rule.MatchNamePattern != "" {
+			if _, err := regexp.Compile(rule.MatchNamePattern); err != nil {
+				return fmt.Errorf("override %s: %w", rule.ID, err)
+			}
+		}
+		if rule.SetCategoryID != "" {
+			if _, ok := DefaultTaxonomy().Lookup(rule.SetCategoryID); !ok {
+				return fmt.Errorf("override %s: unknown category %s", rule.ID, rule.SetCategoryID)
+			}
+		}
+		if rule.SetCategoryID == "" && rule.SetTransactionType == nil && rule.SetPhysicalLocation == nil {
+			return fmt.Errorf("override %s: rule changes nothing", rule.ID)
+		}
+		if rule.MatchMinAmount != nil && rule.MatchMaxAmount != nil && *rule.MatchMinAmount > *rule.MatchMaxAmount {
+			return fmt.Errorf("override %s: min amount above max amount", rule.ID)
+		}
+	}
+	return nil
+}
+
+// compile sorts each stage by priority, then oldest first, then id so the outcome never
+// depends on storage order.  Bad patterns are kept as nil and never match, Validate reports them.
+// The result is reused until Rules changes
+func (set *OverrideRuleSet) compile() *compiledOverrides {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	if set.compiled != nil && set.compiled.current(set.Rules) {
+		return set.compiled
+	}
+	compiled := &compiledOverrides{stages: map[OverrideStage][]OverrideRule{}, patterns: map[string]*regexp.Regexp{}}
+	for _, rule := range set.Rules {
+		rule = rule.clone()
+		compiled.rules = append(compiled.rules, rule)
+		compiled.stages[rule.Stage] = append(compiled.stages[rule.Stage], rule)
+		if _, done := compiled.patterns[rule.MatchNamePattern]; rule.MatchNamePattern != "" && !done {
+			compiled.patterns[rule.MatchNamePattern], _ = regexp.Compile(rule.MatchNamePattern)
+		}
+	}
+	for _, rules := range compiled.stages {
+		sort.SliceStable(rules, func(i, j int) bool {
+			if rules[i].Priority != rules[j].Priority {
+				return rules[i].Priority > rules[j].Priority
+			}
+			if !rules[i].Added.Equal(rules[j].Added) {
+				return rules[i].Added.Before(rules[j].Added)
+			}
+			return rules[i].ID < rules[j].ID
+		})
+	}
+	set.compiled = compiled
+	return compiled
+}
+
+func (compiled *compiledOverrides) matches(rule OverrideRule, transaction CowTransaction, categoryID string) bool {
+	if rule.MatchMerchantName != "" && !strings.EqualFold(strings.TrimSpace(transaction.MerchantName), strings.TrimSpace(rule.MatchMerchantName)) {
+		return false
+	}
+	if rule.MatchNamePattern != "" {
+		pattern := compiled.patterns[rule.MatchNamePattern]
+		if pattern == nil || !(pattern.MatchString(transaction.Name) || pattern.MatchString(transaction.OriginalDescription)) {
+			return false
+		}
+	}
+	if rule.MatchAccountID != "" && rule.MatchAccountID != transaction.AccountID {
+		return false
+	}
+	if rule.MatchCategoryID != "" && rule.MatchCategoryID != categoryID {
+		return false
+	}
+	if rule.MatchMinAmount != nil && transaction.Amount < *rule.MatchMinAmount {
+		return false
+	}
+	if rule.MatchMaxAmount != nil && transaction.Amount > *rule.MatchMaxAmount {
+		return false
+	}
+	return true
+}
+
+func applyOverride(rule OverrideRule, found TransactionMap, amount float64) TransactionMap {
+	if rule.SetCategoryID != "" {
+		if target, ok := DefaultTaxonomy().Lookup(rule.SetCategoryID); ok {
+			found = withAmountSign(target, amount)
+		}
+	}
+	if rule.SetTransactionType != nil {
+		found.TransactionType = *rule.SetTransactionType
+	}
+	if rule.SetPhysicalLocation != nil {
+		found.PhysicalLocation = *rule.SetPhysicalLocation
+	}
+	return found
+}
+
+// Match returns the first rule of a stage that fires for the transaction.  categoryID is
+// what MatchCategoryID is checked against - the plaid id before, the classified id after
+func (set *OverrideRuleSet) Match(stage OverrideStage, transaction CowTransaction, categoryID string) (OverrideRule, bool) {
+	if set == nil || (set.UID != "" && set.UID != transaction.UID) {
+		return OverrideRule{}, false
+	}
+	compiled := set.compile()
+	for _, rule := range compiled.stages[stage] {
+		if compiled.matches(rule, transaction, categoryID) {
+			return rule, true
+		}
+	}
+	return OverrideRule{}, false
+}
+
+// Classify runs the user's rules around Classify.  The first matching before rule is applied
+// to the plain category id lookup and wins, otherwise the first matching after rule patches
+// the normal result
+func (set *OverrideRuleSet) Classify(transaction CowTransaction) TransactionMap {
+	return (&Classifier{Overrides: set}).Classify(transaction)
+}
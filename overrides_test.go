@@ -0,0 +1,96 @@
+package spacecow_common
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOverrideRulesOrderAndStages(t *testing.T) {
+	credit := XactionType(XactionCredit)
+	older := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	set := &OverrideRuleSet{UID: "u", Rules: []OverrideRule{
+		{ID: "newer", Stage: OverrideBefore, Added: older.Add(time.Hour), MatchNamePattern: "(?i)venmo", SetCategoryID: "13000000"},
+		{ID: "older", Stage: OverrideBefore, Added: older, MatchNamePattern: "(?i)venmo", SetCategoryID: "12000000"},
+		{ID: "urgent", Stage: OverrideBefore, Priority: 5, MatchMerchantName: "Landlord LLC", SetCategoryID: "18000000"},
+		{ID: "after", Stage: OverrideAfter, MatchCategoryID: "13005000", SetTransactionType: &credit},
+	}}
+	if err := set.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := set.Classify(CowTransaction{UID: "u", Name: "VENMO 1234", Amount: 20}); got.ID != "12000000" {
+		t.Errorf("oldest rule should win a tie, got %s", got.ID)
+	}
+	if got := set.Classify(CowTransaction{UID: "u", Name: "VENMO 1234", MerchantName: "landlord llc", Amount: 20}); got.ID != "18000000" {
+		t.Errorf("priority should win, got %s", got.ID)
+	}
+	if got := set.Classify(CowTransaction{UID: "u", CategoryID: "13005000", Amount: 20}); got.ID != "13005000" || got.TransactionType != XactionCredit {
+		t.Errorf("after rule: got %+v", got)
+	}
+	if got := set.Classify(CowTransaction{UID: "someone else", Name: "VENMO", CategoryID: "13005000", Amount: 20}); got.TransactionType != XactionCharge {
+		t.Errorf("another user's rules applied: %+v", got)
+	}
+}
+
+func TestOverrideRulesChanged(t *testing.T) {
+	set := &OverrideRuleSet{Rules: []OverrideRule{{ID: "a", MatchNamePattern: "ACME", SetCategoryID: "12000000"}}}
+	transaction := CowTransaction{Name: "ACME", Amount: 1}
+	if got := set.Classify(transaction); got.ID != "12000000" {
+		t.Fatalf("got %s", got.ID)
+	}
+	set.Rules[0].SetCategoryID = "13000000"
+	if got := set.Classify(transaction); got.ID != "13000000" {
+		t.Fatalf("edited rule not recompiled, got %s", got.ID)
+	}
+	low := 5.0
+	set.Rules[0].MatchMinAmount = &low
+	if got := set.Classify(transaction); got.ID == "13000000" {
+		t.Fatalf("minimum amount ignored, got %s", got.ID)
+	}
+	low = 0.5
+	if got := set.Classify(transaction); got.ID != "13000000" {
+		t.Fatalf("minimum amount changed in place ignored, got %s", got.ID)
+	}
+	set.Rules = append(set.Rules, OverrideRule{ID: "b", Priority: 1, MatchNamePattern: "ACME", SetCategoryID: "19000000"})
+	if got := set.Classify(transaction); got.ID != "19000000" {
+		t.Fatalf("added rule not recompiled, got %s", got.ID)
+	}
+}
+
+func TestOverrideValidate(t *testing.T) {
+	low, high := 10.0, 5.0
+	for name, rule := range map[string]OverrideRule{
+		"pattern":  {ID: "x", MatchNamePattern: "(", SetCategoryID: "12000000"},
+		"category": {ID: "x", SetCategoryID: "nope"},
+		"nothing":  {ID: "x", MatchNamePattern: "a"},
+		"range":    {ID: "x", MatchMinAmount: &low, MatchMaxAmount: &high, SetCategoryID: "12000000"},
+	} {
+		set := &OverrideRuleSet{Rules: []OverrideRule{rule}}
+		if err := set.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		// a bad pattern never matches rather than panicking
+		set.Classify(CowTransaction{Name: "a"})
+	}
+}
+
+// run with -race, sets are shared between request handlers
+func TestOverrideRulesConcurrent(t *testing.T) {
+	set := &OverrideRuleSet{Rules: []OverrideRule{
+		{ID: "a", MatchNamePattern: "(?i)acme", SetCategoryID: "12000000"},
+		{ID: "b", Stage: OverrideAfter, MatchCategoryID: "13000000", SetCategoryID: "19000000"},
+	}}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if got := set.Classify(CowTransaction{Name: "Acme", Amount: 1}); got.ID != "12000000" {
+					t.Errorf("got %s", got.ID)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,167 @@
+package spacecow_common
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+//go:embed data/pfc.csv
+var embeddedPFCMap []byte
+
+var defaultPFCMap = mustParsePFCMap(embeddedPFCMap)
+
+var pfcHeader = []string{"pfc", "category_id"}
+
+// PersonalFinanceCategory is Plaid's replacement for the legacy category id, a primary
+// value like FOOD_AND_DRINK and a detailed value like FOOD_AND_DRINK_COFFEE
+type PersonalFinanceCategory struct {
+	Primary         string `json:"primary" bson:"primary"`
+	Detailed        string `json:"detailed" bson:"detailed"`
+	ConfidenceLevel string `json:"confidence_level" bson:"confidenceLevel"`
+}
+
+// PFCMap translates personal finance categories into taxonomy category ids
+type PFCMap struct {
+	Version    string
+	categories map[string]string
+	primaries  []string
+}
+
+// DefaultPFCMap is the pfc mapping embedded in this package
+func DefaultPFCMap() *PFCMap {
+	return defaultPFCMap
+}
+
+// ParsePFCMap reads a pfc,category_id csv in the same layout as the taxonomy file
+func ParsePFCMap(r io.Reader) (*PFCMap, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	m := &PFCMap{Version: dataFileVersion(raw), categories: map[string]string{}}
+	reader, err := newDataFileReader(raw, pfcHeader)
+	if err != nil {
+		return nil, fmt.Errorf("pfc map %w", err)
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		pfc := strings.ToUpper(strings.TrimSpace(record[0]))
+		if _, dup := m.categories[pfc]; dup {
+			return nil, fmt.Errorf("pfc map: duplicate value %s", pfc)
+		}
+		m.categories[pfc] = strings.TrimSpace(record[1])
+	}
+	m.primaries = m.findPrimaries()
+	return m, nil
+}
+
+func mustParsePFCMap(raw []byte) *PFCMap {
+	m, err := ParsePFCMap(bytes.NewReader(raw))
+	if err != nil {
+		panic("embedded pfc map: " + err.Error())
+	}
+	return m
+}
+
+// CategoryID finds the taxonomy id for a pfc - the detailed value wins, then the primary
+func (m *PFCMap) CategoryID(pfc PersonalFinanceCategory) (string, bool) {
+	if id, ok := m.categories[pfc.Detailed]; ok && pfc.Detailed != "" {
+		return id, true
+	}
+	if id, ok := m.categories[pfc.Primary]; ok && pfc.Primary != "" {
+		return id, true
+	}
+	return "", false
+}
+
+// findPrimaries picks the top level values out of the map, longest first so prefix matching is unambiguous
+func (m *PFCMap) findPrimaries() []string {
+	var out []string
+	for pfc := range m.categories {
+		isPrimary := true
+		for other := range m.categories {
+			if other != pfc && strings.HasPrefix(pfc, other+"_") {
+				isPrimary = false
+				break
+			}
+		}
+		if isPrimary {
+			out = append(out, pfc)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i]) != len(out[j]) {
+			return len(out[i]) > len(out[j])
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+// ParsePersonalFinanceCategory understands what ends up in CowTransaction.PersonalFinanceCategory -
+// either the plaid json object or a bare primary or detailed value
+func ParsePersonalFinanceCategory(raw string) (PersonalFinanceCategory, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "null" {
+		return PersonalFinanceCategory{}, false
+	}
+	var pfc PersonalFinanceCategory
+	if strings.HasPrefix(raw, "{") {
+		if err := json.Unmarshal([]byte(raw), &pfc); err != nil {
+			return PersonalFinanceCategory{}, false
+		}
+	} else {
+		pfc.Detailed = raw
+	}
+	pfc.Primary = strings.ToUpper(strings.TrimSpace(pfc.Primary))
+	pfc.Detailed = strings.ToUpper(strings.TrimSpace(pfc.Detailed))
+	if pfc.Primary == "" {
+		for _, primary := range defaultPFCMap.primaries {
+			if pfc.Detailed == primary || strings.HasPrefix(pfc.Detailed, primary+"_") {
+				pfc.Primary = primary
+				break
+			}
+		}
+	}
+	if pfc.Primary == "" {
+		return PersonalFinanceCategory{}, false
+	}
+	if pfc.Detailed == pfc.Primary {
+		pfc.Detailed = ""
+	}
+	return pfc, true
+}
+
+// PFC parses the stored personal finance category of the transaction
+func (transaction CowTransaction) PFC() (PersonalFinanceCategory, bool) {
+	return ParsePersonalFinanceCategory(transaction.PersonalFinanceCategory)
+}
+
+// ClassifyPFC maps a transaction through its personal finance category only
+func ClassifyPFC(transaction CowTransaction) (TransactionMap, bool) {
+	pfc, ok := transaction.PFC()
+	if !ok {
+		return TransactionMap{}, false
+	}
+	id, ok := DefaultPFCMap().CategoryID(pfc)
+	if !ok {
+		return TransactionMap{}, false
+	}
+	found, ok := DefaultTaxonomy().Lookup(id)
+	if !ok {
+		return TransactionMap{}, false
+	}
+	return withAmountSign(found, transaction.Amount), true
+}
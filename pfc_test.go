@@ -0,0 +1,61 @@
+package spacecow_common
+
+import "testing"
+
+func TestParsePersonalFinanceCategory(t *testing.T) {
+	for _, c := range []struct {
+		raw      string
+		ok       bool
+		primary  string
+		detailed string
+	}{
+		{`{"primary":"FOOD_AND_DRINK","detailed":"FOOD_AND_DRINK_BEER_WINE_AND_LIQUOR","confidence_level":"HIGH"}`, true, "FOOD_AND_DRINK", "FOOD_AND_DRINK_BEER_WINE_AND_LIQUOR"},
+		{"bank_fees_atm_fees", true, "BANK_FEES", "BANK_FEES_ATM_FEES"},
+		{"BANK_FEES", true, "BANK_FEES", ""},
+		{"", false, "", ""},
+		{"null", false, "", ""},
+		{"{not json", false, "", ""},
+		{"SOMETHING_NEW", false, "", ""},
+	} {
+		pfc, ok := ParsePersonalFinanceCategory(c.raw)
+		if ok != c.ok || pfc.Primary != c.primary || pfc.Detailed != c.detailed {
+			t.Errorf("%q: got %+v %v", c.raw, pfc, ok)
+		}
+	}
+}
+
+func TestPFCMapPrefersDetailed(t *testing.T) {
+	m := DefaultPFCMap()
+	if id, ok := m.CategoryID(PersonalFinanceCategory{Primary: "BANK_FEES", Detailed: "BANK_FEES_OVERDRAFT_FEES"}); !ok || id != "10001000" {
+		t.Errorf("detailed: %s %v", id, ok)
+	}
+	if id, ok := m.CategoryID(PersonalFinanceCategory{Primary: "BANK_FEES", Detailed: "BANK_FEES_SOMETHING_NEW"}); !ok || id != "10000000" {
+		t.Errorf("primary fallback: %s %v", id, ok)
+	}
+	if _, ok := m.CategoryID(PersonalFinanceCategory{Primary: "NOPE"}); ok {
+		t.Error("unknown primary matched")
+	}
+}
+
+func TestClassifyPrefersPFC(t *testing.T) {
+	transaction := CowTransaction{CategoryID: "13000000", PersonalFinanceCategory: "BANK_FEES_ATM_FEES", Amount: 3}
+	if got := Classify(transaction); got.ID != "10002000" {
+		t.Errorf("pfc: got %s", got.ID)
+	}
+	transaction.PersonalFinanceCategory = ""
+	if got := Classify(transaction); got.ID != "13000000" {
+		t.Errorf("category id fallback: got %s", got.ID)
+	}
+	transaction.PersonalFinanceCategory = "SOMETHING_NEW"
+	if got := Classify(transaction); got.ID != "13000000" {
+		t.Errorf("unknown pfc: got %s", got.ID)
+	}
+}
+
+func TestEveryPFCMapsIntoTheTaxonomy(t *testing.T) {
+	for pfc, id := range DefaultPFCMap().categories {
+		if _, ok := DefaultTaxonomy().Lookup(id); !ok {
+			t.Errorf("%s maps to unknown category %s", pfc, id)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package spacecow_common
+
+import "math"
+
+// ShipThreshold is the net worth a ship starts at
+type ShipThreshold struct {
+	Ship        ShipTypes `json:"ship" bson:"ship"`
+	MinNetWorth float64   `json:"min_net_worth" bson:"minNetWorth"`
+}
+
+// ShipThresholds are the ships in order, each one starting where the last ends.  Scout is
+// everything below Aberdeen including negative net worth
+var ShipThresholds = []ShipThreshold{
+	{ScoutShip, 0},
+	{AberdeenShip, 5000},
+	{HerefordShip, 50000},
+	{LonghornShip, 100000},
+	{WagyuShip, 1000000},
+}
+
+// ShipHysteresis is how far past a boundary net worth has to go, as a fraction of the boundary,
+// before ShipWithHysteresis changes ship - 5% keeps someone at 4,990-5,010 from flipping daily
+const ShipHysteresis = 0.05
+
+func shipIndex(ship ShipTypes) int {
+	for i, threshold := range ShipThresholds {
+		if threshold.Ship == ship {
+			return i
+		}
+	}
+	return 0
+}
+
+// ShipForNetWorth is the ship for a net worth with no history
+func ShipForNetWorth(netWorth float64) ShipTypes {
+	ship := ShipThresholds[0].Ship
+	for _, threshold := range ShipThresholds {
+		if netWorth >= threshold.MinNetWorth {
+			ship = threshold.Ship
+		}
+	}
+	return ship
+}
+
+// ShipWithHysteresis moves from the current ship only once net worth is ShipHysteresis past a
+// boundary - up when it clears the next ship's start by that much, down when it falls that far
+// below the current ship's start.  Big moves can skip several ships either way
+func ShipWithHysteresis(current ShipTypes, netWorth float64) ShipTypes {
+	target := ShipForNetWorth(netWorth)
+	i, want := shipIndex(current), shipIndex(target)
+	switch {
+	case want > i:
+		// climb as far as the margin allows
+		for want > i && netWorth < ShipThresholds[want].MinNetWorth*(1+ShipHysteresis) {
+			want--
+		}
+		return ShipThresholds[want].Ship
+	case want < i:
+		// fall only as far as needed
+		for want < i && netWorth >= ShipThresholds[want+1].MinNetWorth*(1-ShipHysteresis) {
+			want++
+		}
+		return ShipThresholds[want].Ship
+	}
+	return current
+}
+
+// ShipProgress is how far a user is toward the next ship
+type ShipProgress struct {
+	Ship     ShipTypes `json:"ship" bson:"ship"`
+	Next     ShipTypes `json:"next" bson:"next"` // the same as Ship at the top
+	NetWorth float64   `json:"net_worth" bson:"netWorth"`
+	NextAt   float64   `json:"next_at" bson:"nextAt"` // net worth the next ship starts at
+	ToGo     float64   `json:"to_go" bson:"toGo"`
+	Percent  float64   `json:"percent" bson:"percent"` // 0-100 of the way from this ship's start to the next
+}
+
+// ProgressForNetWorth is the progress from a ship - usually the one ShipWithHysteresis gave -
+// to the next.  Net worth still under the ship's start (inside the hysteresis band) is 0%
+func ProgressForNetWorth(ship ShipTypes, netWorth float64) ShipProgress {
+	i := shipIndex(ship)
+	progress := ShipProgress{Ship: ship, Next: ship, NetWorth: netWorth, Percent: 100}
+	if i == len(ShipThresholds)-1 {
+		return progress
+	}
+	start, next := ShipThresholds[i].MinNetWorth, ShipThresholds[i+1]
+	progress.Next = next.Ship
+	progress.NextAt = next.MinNetWorth
+	progress.ToGo = math.Round(math.Max(next.MinNetWorth-netWorth, 0)*100) / 100
+	percent := (netWorth - start) / (next.MinNetWorth - start) * 100
+	progress.Percent = math.Round(math.Min(math.Max(percent, 0), 100)*10) / 10
+	return progress
+}
@@ -0,0 +1,106 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestShipForNetWorth(t *testing.T) {
+	cases := map[float64]ShipTypes{
+		-2500:   ScoutShip,
+		0:       ScoutShip,
+		4999.99: ScoutShip,
+		5000:    AberdeenShip,
+		99999:   HerefordShip,
+		100000:  LonghornShip,
+		2e6:     WagyuShip,
+	}
+	for netWorth, want := range cases {
+		if got := ShipForNetWorth(netWorth); got != want {
+			t.Errorf("%v: %s, want %s", netWorth, got, want)
+		}
+	}
+}
+
+func TestShipWithHysteresis(t *testing.T) {
+	cases := []struct {
+		current  ShipTypes
+		netWorth float64
+		want     ShipTypes
+	}{
+		{ScoutShip, 5100, ScoutShip},       // past aberdeen but not by 5%
+		{ScoutShip, 5250, AberdeenShip},    // 5% past
+		{ScoutShip, 60000, HerefordShip},   // big jumps skip ships
+		{ScoutShip, 51000, AberdeenShip},   // as far as the margin allows
+		{AberdeenShip, 4900, AberdeenShip}, // dipped under but within 5%
+		{AberdeenShip, 4700, ScoutShip},
+		{LonghornShip, 1000, ScoutShip},
+		{WagyuShip, 960000, WagyuShip},
+	}
+	for _, c := range cases {
+		if got := ShipWithHysteresis(c.current, c.netWorth); got != c.want {
+			t.Errorf("%s at %v: %s, want %s", c.current, c.netWorth, got, c.want)
+		}
+	}
+}
+
+func TestProgressForNetWorth(t *testing.T) {
+	progress := ProgressForNetWorth(AberdeenShip, 27500.1)
+	if progress.Next != HerefordShip || progress.NextAt != 50000 || progress.ToGo != 22499.9 || progress.Percent != 50 {
+		t.Errorf("aberdeen %+v", progress)
+	}
+	// kept in aberdeen by the hysteresis
+	if progress := ProgressForNetWorth(AberdeenShip, 4900); progress.Percent != 0 || progress.ToGo != 45100 {
+		t.Errorf("inside the band %+v", progress)
+	}
+	if progress := ProgressForNetWorth(HerefordShip, 101000); progress.Percent != 100 || progress.ToGo != 0 {
+		t.Errorf("past the next start %+v", progress)
+	}
+	if progress := ProgressForNetWorth(WagyuShip, 2e6); progress.Next != WagyuShip || progress.Percent != 100 {
+		t.Errorf("top %+v", progress)
+	}
+}
+
+func TestShipTypesMarshalling(t *testing.T) {
+	var doc struct {
+		Ship ShipTypes `json:"ship" bson:"ship"`
+	}
+	doc.Ship = LonghornShip
+	raw, err := json.Marshal(doc)
+	if err != nil || string(raw) != `{"ship":"longhorn"}` {
+		t.Fatalf("json %s %v", raw, err)
+	}
+	for _, input := range []string{`{"ship":"Longhorn"}`, `{"ship":3}`} {
+		doc.Ship = ScoutShip
+		if err := json.Unmarshal([]byte(input), &doc); err != nil || doc.Ship != LonghornShip {
+			t.Errorf("%s: %s %v", input, doc.Ship, err)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"ship":"dinghy"}`), &doc); err == nil {
+		t.Error("read an unknown ship")
+	}
+	raw, err = bson.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := bson.Raw(raw).Lookup("ship"); value.Type != bson.TypeInt64 && value.Type != bson.TypeInt32 {
+		t.Errorf("bson stores a %s", value.Type)
+	}
+}
+
+func TestParseSubscriptionLevel(t *testing.T) {
+	for level := Trial; level <= Hyperpasturized; level++ {
+		parsed, err := ParseSubscriptionLevel(" " + SubscriptionLevel(level).String() + " ")
+		if err != nil || parsed != SubscriptionLevel(level) {
+			t.Errorf("%d: %v %v", level, parsed, err)
+		}
+	}
+	if _, err := ParseSubscriptionLevel("2%"); err == nil {
+		t.Error("parsed 2%")
+	}
+	if SubscriptionLevel(9).String() != "level(9)" {
+		t.Error(SubscriptionLevel(9).String())
+	}
+}
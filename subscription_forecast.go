@@ -0,0 +1,220 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+)
+
+// UpcomingCharge is one projected charge of a subscription
+type UpcomingCharge struct {
+	UID         string             `json:"uid" bson:"uid"`
+	MerchantKey string             `json:"merchant_key" bson:"merchantKey"`
+	Name        string             `json:"name" bson:"name"`
+	Period      SubscriptionPeriod `json:"period" bson:"period"`
+	Expected    CivilDate          `json:"expected" bson:"expected"` // nominal date
+	Earliest    CivilDate          `json:"earliest" bson:"earliest"` // window the charge should land in
+	Latest      CivilDate          `json:"latest" bson:"latest"`
+	Amount      float64            `json:"amount" bson:"amount"` // most likely amount, the last one charged
+	MinAmount   float64            `json:"min_amount" bson:"minAmount"`
+	MaxAmount   float64            `json:"max_amount" bson:"maxAmount"`
+	Currency    string             `json:"currency" bson:"currency"` // of the last charge, empty when there's no history
+	Confidence  float64            `json:"confidence" bson:"confidence"`
+}
+
+// BillCalendarDay is every charge expected on one day
+type BillCalendarDay struct {
+	Date    CivilDate        `json:"date" bson:"date"`
+	Charges []UpcomingCharge `json:"charges" bson:"charges"`
+	Total   float64          `json:"total" bson:"total"`
+}
+
+// BillCalendar is a user's "what's coming" view over [From, To) in one currency
+type BillCalendar struct {
+	UID      string            `json:"uid" bson:"uid"`
+	Currency string            `json:"currency" bson:"currency"`
+	From     CivilDate         `json:"from" bson:"from"`
+	To       CivilDate         `json:"to" bson:"to"` // exclusive
+	Days     []BillCalendarDay `json:"days" bson:"days"`
+	Total    float64           `json:"total" bson:"total"`
+	MinTotal float64           `json:"min_total" bson:"minTotal"`
+	MaxTotal float64           `json:"max_total" bson:"maxTotal"`
+}
+
+// ChargeForecaster projects subscriptions forward.  Unset, it sizes amount ranges from the last
+// six charges and works in UTC days
+type ChargeForecaster struct {
+	AmountHistory int            // recent charges the amount range is taken from (6)
+	Location      *time.Location // user's zone for dates (UTC)
+}
+
+func (f ChargeForecaster) withDefaults() ChargeForecaster {
+	if f.AmountHistory <= 0 {
+		f.AmountHistory = 6
+	}
+	if f.Location == nil {
+		f.Location = time.UTC
+	}
+	return f
+}
+
+// window is how many days either side of the nominal date charges have actually landed, never
+// wider than the period tolerance
+func (f ChargeForecaster) window(period SubscriptionPeriod, charges []datedCharge) int {
+	tolerance := period.Tolerance()
+	if len(charges) < 2 {
+		return int(math.Ceil(tolerance))
+	}
+	spread := 1.0
+	for i := 1; i < len(charges); i++ {
+		gap := float64(charges[i-1].day.DaysUntil(charges[i].day))
+		if off := math.Abs(gap - period.Days()); off <= tolerance && off > spread {
+			spread = off
+		}
+	}
+	return int(math.Ceil(spread))
+}
+
+// currency is what the subscription was last charged in
+func (f ChargeForecaster) currency(charges []datedCharge) string {
+	if len(charges) == 0 {
+		return ""
+	}
+	return charges[len(charges)-1].transaction.CurrencyCode()
+}
+
+// amountRange is the low and high of the recent charges
+func (f ChargeForecaster) amountRange(subscription PossibleSubscriptions, charges []datedCharge) (float64, float64) {
+	low, high := subscription.Amount, subscription.Amount
+	if len(charges) > f.AmountHistory {
+		charges = charges[len(charges)-f.AmountHistory:]
+	}
+	for _, charge := range charges {
+		low = math.Min(low, charge.amount)
+		high = math.Max(high, charge.amount)
+	}
+	return low, high
+}
+
+// Forecast projects every charge of the subscriptions expected in the days starting at from,
+// soonest first.  history is the users' transactions, used to size the date window and amount
+// range - without it the period tolerance and the last amount are used.  A charge already
+// overdue still shows up while from is inside its window, after that it's the tracker's problem
+func (f ChargeForecaster) Forecast(subscriptions []PossibleSubscriptions, history []CowTransaction, from CivilDate, days int) []UpcomingCharge {
+	f = f.withDefaults()
+	charges := chargesBySubscription(subscriptions, chargesByMerchant(history, f.Location))
+	to := from.AddDays(days)
+	var out []UpcomingCharge
+	for i, subscription := range subscriptions {
+		if subscription.Period == PeriodUnknown || subscription.LastCharge.IsZero() {
+			continue
+		}
+		seen := charges[i]
+		window := f.window(subscription.Period, seen)
+		low, high := f.amountRange(subscription, seen)
+		for n := 1; ; n++ {
+			expected := subscription.Period.Step(subscription.LastCharge, n)
+			if !expected.Before(to) {
+				break
+			}
+			latest := expected.AddDays(window)
+			if latest.Before(from) {
+				continue
+			}
+			out = append(out, UpcomingCharge{
+				UID:         subscription.UID,
+				MerchantKey: subscription.MerchantKey,
+				Name:        subscription.Name,
+				Period:      subscription.Period,
+				Expected:    expected,
+				Earliest:    expected.AddDays(-window),
+				Latest:      latest,
+				Amount:      subscription.Amount,
+				MinAmount:   low,
+				MaxAmount:   high,
+				Currency:    f.currency(seen),
+				Confidence:  subscription.Confidence,
+			})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Expected != out[j].Expected {
+			return out[i].Expected.Before(out[j].Expected)
+		}
+		return out[i].MerchantKey < out[j].MerchantKey
+	})
+	return out
+}
+
+// calendarTotals are a calendar's sums kept exact until they're written out
+type calendarTotals struct {
+	days               []Money
+	total, least, most Money
+}
+
+// Calendar groups a forecast into one BillCalendar per user and currency, ordered by UID then
+// currency.  Overdue charges are put on from rather than a day before the calendar starts
+func (f ChargeForecaster) Calendar(subscriptions []PossibleSubscriptions, history []CowTransaction, from CivilDate, days int) []BillCalendar {
+	byKey := map[string]*BillCalendar{}
+	totals := map[string]*calendarTotals{}
+	var keys []string
+	for _, charge := range f.Forecast(subscriptions, history, from, days) {
+		key := charge.UID + "\x00" + charge.Currency
+		calendar, ok := byKey[key]
+		if !ok {
+			calendar = &BillCalendar{UID: charge.UID, Currency: charge.Currency, From: from, To: from.AddDays(days)}
+			byKey[key] = calendar
+			totals[key] = &calendarTotals{}
+			keys = append(keys, key)
+		}
+		sums := totals[key]
+		day := charge.Expected
+		if day.Before(from) {
+			day = from
+		}
+		if last := len(calendar.Days) - 1; last < 0 || calendar.Days[last].Date != day {
+			calendar.Days = append(calendar.Days, BillCalendarDay{Date: day})
+			sums.days = append(sums.days, NewMoney(0, charge.Currency))
+		}
+		entry := &calendar.Days[len(calendar.Days)-1]
+		entry.Charges = append(entry.Charges, charge)
+		amount := MoneyFromFloat(charge.Amount, charge.Currency, RoundHalfEven)
+		// one currency per calendar so these can't mismatch
+		sums.days[len(sums.days)-1], _ = sums.days[len(sums.days)-1].Add(amount)
+		sums.total, _ = sums.total.Add(amount)
+		sums.least, _ = sums.least.Add(MoneyFromFloat(charge.MinAmount, charge.Currency, RoundHalfEven))
+		sums.most, _ = sums.most.Add(MoneyFromFloat(charge.MaxAmount, charge.Currency, RoundHalfEven))
+	}
+	sort.Strings(keys)
+	out := make([]BillCalendar, 0, len(keys))
+	for _, key := range keys {
+		calendar, sums := byKey[key], totals[key]
+		for i := range calendar.Days {
+			calendar.Days[i].Total = sums.days[i].Float64()
+		}
+		calendar.Total = sums.total.Float64()
+		calendar.MinTotal = sums.least.Float64()
+		calendar.MaxTotal = sums.most.Float64()
+		out = append(out, *calendar)
+	}
+	return out
+}
+
+// RefreshQ wraps the calendar into an EventRefreshUI queue entry for the user
+func (calendar BillCalendar) RefreshQ() Q {
+	extra, _ := json.Marshal(struct {
+		BillCalendar BillCalendar `json:"bill_calendar"`
+	}{calendar})
+	return Q{
+		Added: time.Now().UTC(),
+		UID:   calendar.UID,
+		Event: EventRefreshUI,
+		Extra: string(extra),
+	}
+}
+
+// ForecastBills runs a default ChargeForecaster
+func ForecastBills(subscriptions []PossibleSubscriptions, history []CowTransaction, from CivilDate, days int) []BillCalendar {
+	return ChargeForecaster{}.Calendar(subscriptions, history, from, days)
+}
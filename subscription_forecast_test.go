@@ -0,0 +1,84 @@
+package spacecow_common
+
+import (
+	"testing"
+)
+
+func TestForecastWindowsAndAmounts(t *testing.T) {
+	history := charges(t, "Spotify", 9.99, "2023-01-10", 4, monthly)
+	history[3].Amount = 10.99
+	subscriptions := DetectSubscriptions(history)
+	forecast := ChargeForecaster{}.Forecast(subscriptions, history, civilDay(t, "2023-05-01"), 60)
+	if len(forecast) != 2 {
+		t.Fatalf("forecast %+v", forecast)
+	}
+	// february's 28 day gap is the furthest any charge has been off a nominal month
+	next := forecast[0]
+	if next.Expected.String() != "2023-05-10" || next.Earliest.String() != "2023-05-07" || next.Latest.String() != "2023-05-13" {
+		t.Errorf("window %s %s %s", next.Earliest, next.Expected, next.Latest)
+	}
+	if next.Amount != 10.99 || next.MinAmount != 9.99 || next.MaxAmount != 10.99 {
+		t.Errorf("amounts %+v", next)
+	}
+	// no history to go on, the period tolerance is the window
+	bare := ChargeForecaster{}.Forecast(subscriptions, nil, civilDay(t, "2023-05-01"), 30)
+	if len(bare) != 1 || bare[0].Earliest.String() != "2023-05-05" || bare[0].Currency != "" {
+		t.Errorf("bare %+v", bare)
+	}
+}
+
+func TestForecastKeepsOverdueChargesInTheirWindow(t *testing.T) {
+	history := charges(t, "Spotify", 9.99, "2023-01-10", 4, monthly)
+	subscriptions := DetectSubscriptions(history)
+	forecast := ChargeForecaster{}.Forecast(subscriptions, history, civilDay(t, "2023-05-11"), 10)
+	if len(forecast) != 1 || forecast[0].Expected.String() != "2023-05-10" {
+		t.Fatalf("forecast %+v", forecast)
+	}
+	calendars := ChargeForecaster{}.Calendar(subscriptions, history, civilDay(t, "2023-05-11"), 10)
+	if len(calendars) != 1 || calendars[0].Days[0].Date.String() != "2023-05-11" {
+		t.Errorf("calendar %+v", calendars)
+	}
+}
+
+func TestForecastKeepsPlansApart(t *testing.T) {
+	history := append(charges(t, "Apple", 2.99, "2023-01-03", 4, monthly), charges(t, "Apple", 10.99, "2023-01-20", 4, monthly)...)
+	forecast := ChargeForecaster{}.Forecast(DetectSubscriptions(history), history, civilDay(t, "2023-05-01"), 30)
+	if len(forecast) != 2 {
+		t.Fatalf("forecast %+v", forecast)
+	}
+	for _, charge := range forecast {
+		if charge.MinAmount != charge.Amount || charge.MaxAmount != charge.Amount {
+			t.Errorf("%s plan picked up the other's amounts %+v", charge.Expected, charge)
+		}
+	}
+}
+
+func TestCalendarTotalsPerCurrency(t *testing.T) {
+	var history []CowTransaction
+	for _, merchant := range []string{"Hulu", "Max", "Peacock"} {
+		history = append(history, charges(t, merchant, 0.1, "2023-01-10", 4, monthly)...)
+	}
+	euro := charges(t, "Deezer", 11.99, "2023-01-10", 4, monthly)
+	for i := range euro {
+		euro[i].IsoCurrencyCode = "EUR"
+	}
+	history = append(history, euro...)
+	calendars := ForecastBills(DetectSubscriptions(history), history, civilDay(t, "2023-05-01"), 30)
+	if len(calendars) != 2 {
+		t.Fatalf("calendars %+v", calendars)
+	}
+	dollars, euros := calendars[0], calendars[1]
+	if dollars.Currency != "" || euros.Currency != "EUR" {
+		t.Fatalf("currencies %q %q", dollars.Currency, euros.Currency)
+	}
+	// 0.1 three times is 0.30000000000000004 in floats
+	if dollars.Total != 0.3 || dollars.MaxTotal != 0.3 || len(dollars.Days) != 1 || dollars.Days[0].Total != 0.3 {
+		t.Errorf("dollars %+v", dollars)
+	}
+	if euros.Total != 11.99 || len(euros.Days[0].Charges) != 1 {
+		t.Errorf("euros %+v", euros)
+	}
+	if q := euros.RefreshQ(); q.Event != EventRefreshUI || q.UID != "u1" {
+		t.Errorf("refresh %+v", q)
+	}
+}
@@ -0,0 +1,259 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+)
+
+// SubscriptionChangeKind is what happened to a tracked subscription
+type SubscriptionChangeKind int
+
+const (
+	SubscriptionPriceIncrease   SubscriptionChangeKind = iota
+	SubscriptionMissedCharge                           // an expected charge never came, probably cancelled
+	SubscriptionDuplicateCharge                        // charged more than once in one period
+)
+
+var subscriptionChangeKindNames = enumNames[SubscriptionChangeKind]{kind: "change", names: map[SubscriptionChangeKind]string{
+	SubscriptionPriceIncrease:   "price_increase",
+	SubscriptionMissedCharge:    "missed_charge",
+	SubscriptionDuplicateCharge: "duplicate_charge",
+}}
+
+func (k SubscriptionChangeKind) String() string {
+	return subscriptionChangeKindNames.name(k)
+}
+
+// MarshalJSON writes "price_increase", "missed_charge" or "duplicate_charge" - the app picks
+// the alert text off it
+func (k SubscriptionChangeKind) MarshalJSON() ([]byte, error) {
+	return subscriptionChangeKindNames.marshalJSON(k)
+}
+
+// UnmarshalJSON reads the change names back
+func (k *SubscriptionChangeKind) UnmarshalJSON(raw []byte) error {
+	return subscriptionChangeKindNames.unmarshalJSON(raw, k)
+}
+
+// SubscriptionChange is one thing worth telling the user about a subscription
+type SubscriptionChange struct {
+	Kind           SubscriptionChangeKind `json:"kind" bson:"kind"`
+	UID            string                 `json:"uid" bson:"uid"`
+	MerchantKey    string                 `json:"merchant_key" bson:"merchantKey"`
+	Name           string                 `json:"name" bson:"name"`
+	OldAmount      float64                `json:"old_amount" bson:"oldAmount"`
+	NewAmount      float64                `json:"new_amount" bson:"newAmount"`
+	Date           CivilDate              `json:"date" bson:"date"`         // when the new price or the duplicate showed up
+	Expected       CivilDate              `json:"expected" bson:"expected"` // the charge date we were waiting for
+	TransactionIDs []string               `json:"transaction_ids" bson:"transactionIds"`
+}
+
+// SubscriptionUpdate is the tracked subscriptions after a batch plus what changed
+type SubscriptionUpdate struct {
+	Subscriptions []PossibleSubscriptions `json:"subscriptions" bson:"subscriptions"`
+	Changes       []SubscriptionChange    `json:"changes" bson:"changes"`
+}
+
+// SubscriptionTracker follows detected subscriptions as new transactions arrive.  Left empty it
+// ignores price moves under a cent, treats charges within 15% as duplicates and gives a late
+// charge three days past the period tolerance
+type SubscriptionTracker struct {
+	PriceTolerance  float64        // amount change ignored as noise (0.005, half a cent)
+	AmountTolerance float64        // fraction two charges can differ and still be duplicates (0.15)
+	GraceDays       int            // days past the period tolerance before a charge counts as missed (3)
+	Location        *time.Location // user's zone for dates (UTC)
+}
+
+func (t SubscriptionTracker) withDefaults() SubscriptionTracker {
+	if t.PriceTolerance <= 0 {
+		t.PriceTolerance = 0.005
+	}
+	if t.AmountTolerance <= 0 {
+		t.AmountTolerance = 0.15
+	}
+	if t.GraceDays <= 0 {
+		t.GraceDays = 3
+	}
+	if t.Location == nil {
+		t.Location = time.UTC
+	}
+	return t
+}
+
+// subscriptionKey matches subscriptions with the charges in a batch
+func subscriptionKey(uid string, merchant string) string {
+	return uid + "\x00" + merchant
+}
+
+// chargesKey is the chargesByMerchant group a subscription's charges are in
+func (subscription PossibleSubscriptions) chargesKey() string {
+	key := subscription.MerchantKey
+	if key == "" {
+		key = NormalizeMerchant(subscription.Name).Key
+	}
+	return subscriptionKey(subscription.UID, key)
+}
+
+// chargesBySubscription lines each subscription up with its charges from the chargesByMerchant
+// groups.  When a merchant has several plans each charge goes to the one closest in amount
+func chargesBySubscription(subscriptions []PossibleSubscriptions, groups map[string][]datedCharge) [][]datedCharge {
+	out := make([][]datedCharge, len(subscriptions))
+	plans := map[string][]int{}
+	for i, subscription := range subscriptions {
+		key := subscription.chargesKey()
+		plans[key] = append(plans[key], i)
+	}
+	for key, indexes := range plans {
+		for _, charge := range groups[key] {
+			closest := indexes[0]
+			for _, i := range indexes[1:] {
+				if math.Abs(charge.amount-subscriptions[i].Amount) < math.Abs(charge.amount-subscriptions[closest].Amount) {
+					closest = i
+				}
+			}
+			out[closest] = append(out[closest], charge)
+		}
+	}
+	return out
+}
+
+// ExpectedNext is the nominal date of the next charge of a detected subscription
+func (subscription PossibleSubscriptions) ExpectedNext() CivilDate {
+	if subscription.LastCharge.IsZero() {
+		return CivilDate{}
+	}
+	return subscription.Period.Next(subscription.LastCharge)
+}
+
+// counted is true for a charge an earlier batch already took into account.  Records from before
+// LastChargeIDs existed only have the date to go on
+func (subscription PossibleSubscriptions) counted(charge datedCharge) bool {
+	if charge.day.Before(subscription.LastCharge) {
+		return true
+	}
+	id := charge.transaction.TransactionID
+	if id == "" || len(subscription.LastChargeIDs) == 0 {
+		return !charge.day.After(subscription.LastCharge)
+	}
+	for _, known := range subscription.LastChargeIDs {
+		if known == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Track applies a batch of new transactions to previously detected subscriptions and reports
+// price increases, duplicate charges and, as of the given day, charges that never came.  A
+// missed charge is reported once, the returned subscriptions remember it was
+func (t SubscriptionTracker) Track(previous []PossibleSubscriptions, batch []CowTransaction, asOf CivilDate) SubscriptionUpdate {
+	t = t.withDefaults()
+	charges := chargesBySubscription(previous, chargesByMerchant(batch, t.Location))
+	update := SubscriptionUpdate{}
+	for i, subscription := range previous {
+		current, changes := t.trackOne(subscription, charges[i], asOf)
+		update.Subscriptions = append(update.Subscriptions, current)
+		update.Changes = append(update.Changes, changes...)
+	}
+	sort.SliceStable(update.Changes, func(i, j int) bool {
+		return update.Changes[i].Date.Before(update.Changes[j].Date)
+	})
+	return update
+}
+
+func (t SubscriptionTracker) trackOne(subscription PossibleSubscriptions, charges []datedCharge, asOf CivilDate) (PossibleSubscriptions, []SubscriptionChange) {
+	var changes []SubscriptionChange
+	window := subscription.Period.Days() / 2
+	// the charge everything new is compared with, starting from what detection saw last
+	previousDay, previousAmount, previousID := subscription.LastCharge, subscription.Amount, ""
+	if n := len(subscription.LastChargeIDs); n > 0 {
+		previousID = subscription.LastChargeIDs[n-1]
+	}
+	for _, charge := range charges {
+		if subscription.counted(charge) {
+			continue // already counted in an earlier batch
+		}
+		found := SubscriptionChange{
+			UID:            subscription.UID,
+			MerchantKey:    subscription.MerchantKey,
+			Name:           subscription.Name,
+			OldAmount:      subscription.Amount,
+			NewAmount:      charge.amount,
+			Date:           charge.day,
+			Expected:       subscription.ExpectedNext(),
+			TransactionIDs: []string{charge.transaction.TransactionID},
+		}
+		duplicate := !previousDay.IsZero() &&
+			float64(previousDay.DaysUntil(charge.day)) < window &&
+			math.Abs(charge.amount-previousAmount) <= previousAmount*t.AmountTolerance
+		switch {
+		case duplicate:
+			found.Kind = SubscriptionDuplicateCharge
+			found.OldAmount = previousAmount
+			if previousID != "" {
+				found.TransactionIDs = append([]string{previousID}, found.TransactionIDs...)
+			}
+			changes = append(changes, found)
+			subscription.LastChargeIDs = append(subscription.LastChargeIDs, charge.transaction.TransactionID)
+			continue // the schedule stays anchored on the first one
+		case charge.amount > subscription.Amount+t.PriceTolerance:
+			found.Kind = SubscriptionPriceIncrease
+			changes = append(changes, found)
+			fallthrough
+		default:
+			subscription.Amount = charge.amount
+		}
+		if charge.day != subscription.LastCharge {
+			subscription.LastChargeIDs = nil
+		}
+		subscription.LastCharge = charge.day
+		subscription.LastChargeIDs = append(subscription.LastChargeIDs, charge.transaction.TransactionID)
+		subscription.Instances++
+		previousDay, previousAmount, previousID = charge.day, charge.amount, charge.transaction.TransactionID
+	}
+	expected := subscription.ExpectedNext()
+	if expected.IsZero() {
+		return subscription, changes
+	}
+	deadline := expected.AddDays(int(math.Ceil(subscription.Period.Tolerance())) + t.GraceDays)
+	if asOf.After(deadline) && subscription.MissedNotified != expected {
+		subscription.MissedNotified = expected
+		changes = append(changes, SubscriptionChange{
+			Kind:        SubscriptionMissedCharge,
+			UID:         subscription.UID,
+			MerchantKey: subscription.MerchantKey,
+			Name:        subscription.Name,
+			OldAmount:   subscription.Amount,
+			Date:        asOf,
+			Expected:    expected,
+		})
+	}
+	return subscription, changes
+}
+
+// Events turns an update into queue entries - an EventAlert per change and one
+// EventUpdateSubscriptions per user whose subscriptions need saving
+func (update SubscriptionUpdate) Events() []Q {
+	now := time.Now().UTC()
+	var out []Q
+	seen := map[string]bool{}
+	for _, subscription := range update.Subscriptions {
+		if seen[subscription.UID] {
+			continue
+		}
+		seen[subscription.UID] = true
+		out = append(out, Q{Added: now, UID: subscription.UID, Event: EventUpdateSubscriptions})
+	}
+	for _, change := range update.Changes {
+		extra, _ := json.Marshal(change)
+		out = append(out, Q{Added: now, UID: change.UID, Event: EventAlert, Extra: string(extra)})
+	}
+	return out
+}
+
+// TrackSubscriptions runs a default SubscriptionTracker
+func TrackSubscriptions(previous []PossibleSubscriptions, batch []CowTransaction, asOf CivilDate) SubscriptionUpdate {
+	return SubscriptionTracker{}.Track(previous, batch, asOf)
+}
@@ -0,0 +1,125 @@
+package spacecow_common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func trackedNetflix(t *testing.T) PossibleSubscriptions {
+	t.Helper()
+	found := DetectSubscriptions(charges(t, "Netflix", 15.49, "2023-01-15", 4, monthly))
+	if len(found) != 1 {
+		t.Fatalf("detected %+v", found)
+	}
+	return found[0]
+}
+
+func netflixCharge(id string, date string, amount float64) CowTransaction {
+	return CowTransaction{TransactionID: id, UID: "u1", MerchantName: "Netflix", Name: "Netflix", Amount: amount, Date: date}
+}
+
+func TestTrackPriceIncrease(t *testing.T) {
+	subscription := trackedNetflix(t)
+	update := TrackSubscriptions([]PossibleSubscriptions{subscription}, []CowTransaction{
+		netflixCharge("may", "2023-05-15", 17.99),
+	}, civilDay(t, "2023-05-16"))
+	if len(update.Changes) != 1 || update.Changes[0].Kind != SubscriptionPriceIncrease {
+		t.Fatalf("changes %+v", update.Changes)
+	}
+	change := update.Changes[0]
+	if change.OldAmount != 15.49 || change.NewAmount != 17.99 || change.Expected.String() != "2023-05-15" {
+		t.Errorf("change %+v", change)
+	}
+	tracked := update.Subscriptions[0]
+	if tracked.Amount != 17.99 || tracked.LastCharge.String() != "2023-05-15" || tracked.Instances != 5 {
+		t.Errorf("tracked %+v", tracked)
+	}
+}
+
+func TestTrackSeveralPlansFromOneMerchant(t *testing.T) {
+	history := append(charges(t, "Apple", 2.99, "2023-01-03", 4, monthly), charges(t, "Apple", 10.99, "2023-01-20", 4, monthly)...)
+	plans := DetectSubscriptions(history)
+	if len(plans) != 2 {
+		t.Fatalf("detected %+v", plans)
+	}
+	update := TrackSubscriptions(plans, append(charges(t, "Apple", 2.99, "2023-05-03", 1, monthly),
+		charges(t, "Apple", 10.99, "2023-05-20", 1, monthly)...), civilDay(t, "2023-05-21"))
+	if len(update.Changes) != 0 {
+		t.Errorf("plans mixed up %+v", update.Changes)
+	}
+	for _, tracked := range update.Subscriptions {
+		if tracked.Instances != 5 {
+			t.Errorf("tracked %+v", tracked)
+		}
+	}
+}
+
+func TestTrackMissedChargeOnlyOnce(t *testing.T) {
+	subscriptions := []PossibleSubscriptions{trackedNetflix(t)}
+	update := TrackSubscriptions(subscriptions, nil, civilDay(t, "2023-05-20"))
+	if len(update.Changes) != 0 {
+		t.Fatalf("missed inside the grace period %+v", update.Changes)
+	}
+	update = TrackSubscriptions(update.Subscriptions, nil, civilDay(t, "2023-05-24"))
+	if len(update.Changes) != 1 || update.Changes[0].Kind != SubscriptionMissedCharge || update.Changes[0].Expected.String() != "2023-05-15" {
+		t.Fatalf("changes %+v", update.Changes)
+	}
+	for _, later := range []string{"2023-05-25", "2023-06-30"} {
+		update = TrackSubscriptions(update.Subscriptions, nil, civilDay(t, later))
+		if len(update.Changes) != 0 {
+			t.Errorf("%s: missed charge reported again %+v", later, update.Changes)
+		}
+	}
+	// it came back, so the next miss is news again
+	update = TrackSubscriptions(update.Subscriptions, []CowTransaction{netflixCharge("jul", "2023-07-01", 15.49)}, civilDay(t, "2023-08-10"))
+	if len(update.Changes) != 1 || update.Changes[0].Expected.String() != "2023-08-01" {
+		t.Errorf("changes %+v", update.Changes)
+	}
+}
+
+func TestTrackDuplicates(t *testing.T) {
+	subscriptions := []PossibleSubscriptions{trackedNetflix(t)}
+	may := netflixCharge("may", "2023-05-15", 15.49)
+	update := TrackSubscriptions(subscriptions, []CowTransaction{may}, civilDay(t, "2023-05-15"))
+	if len(update.Changes) != 0 {
+		t.Fatalf("changes %+v", update.Changes)
+	}
+	// plaid sends the same transaction again plus a second charge the same day
+	again := netflixCharge("may-2", "2023-05-15", 15.49)
+	update = TrackSubscriptions(update.Subscriptions, []CowTransaction{may, again}, civilDay(t, "2023-05-16"))
+	if len(update.Changes) != 1 || update.Changes[0].Kind != SubscriptionDuplicateCharge {
+		t.Fatalf("changes %+v", update.Changes)
+	}
+	if ids := update.Changes[0].TransactionIDs; len(ids) != 2 || ids[0] != "may" || ids[1] != "may-2" {
+		t.Errorf("ids %v", ids)
+	}
+	update = TrackSubscriptions(update.Subscriptions, []CowTransaction{may, again}, civilDay(t, "2023-05-17"))
+	if len(update.Changes) != 0 {
+		t.Errorf("duplicate reported twice %+v", update.Changes)
+	}
+	if update.Subscriptions[0].Instances != 5 {
+		t.Errorf("instances %d", update.Subscriptions[0].Instances)
+	}
+}
+
+func TestSubscriptionChangeJSON(t *testing.T) {
+	raw, err := json.Marshal(SubscriptionChange{Kind: SubscriptionMissedCharge})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back SubscriptionChange
+	if err := json.Unmarshal(raw, &back); err != nil || back.Kind != SubscriptionMissedCharge {
+		t.Errorf("%s: %v %v", raw, back.Kind, err)
+	}
+	if err := json.Unmarshal([]byte(`{"kind":"refund"}`), &back); err == nil {
+		t.Error("read an unknown kind")
+	}
+}
+
+func TestSubscriptionUpdateEvents(t *testing.T) {
+	update := TrackSubscriptions([]PossibleSubscriptions{trackedNetflix(t)}, nil, civilDay(t, "2023-06-01"))
+	events := update.Events()
+	if len(events) != 2 || events[0].Event != EventUpdateSubscriptions || events[1].Event != EventAlert {
+		t.Fatalf("events %+v", events)
+	}
+}
@@ -0,0 +1,383 @@
+package spacecow_common
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// SubscriptionPeriod is how often a recurring charge comes around
+type SubscriptionPeriod int
+
+const (
+	PeriodUnknown SubscriptionPeriod = iota
+	PeriodWeekly
+	PeriodMonthly
+	PeriodQuarterly
+	PeriodAnnual
+	PeriodBiweekly
+	PeriodSemimonthly
+)
+
+type periodSpec struct {
+	period    SubscriptionPeriod
+	name      string
+	days      float64
+	tolerance float64 // days either side of the nominal interval we still accept
+	months    int     // calendar months per period, 0 for the ones counted in days
+}
+
+var periodSpecs = []periodSpec{
+	{PeriodWeekly, "weekly", 7, 2, 0},
+	{PeriodBiweekly, "biweekly", 14, 2, 0},
+	{PeriodSemimonthly, "semimonthly", 15.22, 3, 0},
+	{PeriodMonthly, "monthly", 30.44, 5, 1},
+	{PeriodQuarterly, "quarterly", 91.31, 10, 3},
+	{PeriodAnnual, "annual", 365.25, 20, 12},
+}
+
+func specFor(period SubscriptionPeriod) (periodSpec, bool) {
+	for _, spec := range periodSpecs {
+		if spec.period == period {
+			return spec, true
+		}
+	}
+	return periodSpec{}, false
+}
+
+func (p SubscriptionPeriod) String() string {
+	if spec, ok := specFor(p); ok {
+		return spec.name
+	}
+	return "unknown"
+}
+
+// Days is the nominal length of the period
+func (p SubscriptionPeriod) Days() float64 {
+	spec, _ := specFor(p)
+	return spec.days
+}
+
+// Tolerance is how many days early or late a charge can be and still count as on schedule
+func (p SubscriptionPeriod) Tolerance() float64 {
+	spec, _ := specFor(p)
+	return spec.tolerance
+}
+
+// Next is the nominal date of the charge after from - monthly and longer periods step by
+// calendar month so a charge on the 15th stays on the 15th
+func (p SubscriptionPeriod) Next(from CivilDate) CivilDate {
+	return p.Step(from, 1)
+}
+
+// Step is the nominal date n charges after from.  Stepping in one go rather than calling Next
+// n times keeps a charge on the 31st from drifting to the 28th after February
+func (p SubscriptionPeriod) Step(from CivilDate, n int) CivilDate {
+	spec, ok := specFor(p)
+	switch {
+	case !ok:
+		return from
+	case p == PeriodSemimonthly:
+		return stepHalfMonths(from, n)
+	case spec.months > 0:
+		return from.AddMonths(spec.months * n)
+	}
+	return from.AddDays(int(spec.days) * n)
+}
+
+// stepHalfMonths moves whole months for every two steps and fifteen days, kept inside the
+// calendar, for an odd one left over - the 1st goes to the 16th and the 20th to the 5th
+func stepHalfMonths(from CivilDate, n int) CivilDate {
+	months := n / 2
+	if n%2 != 0 && n < 0 {
+		months-- // round down so the odd half step always goes forward
+	}
+	day := from.AddMonths(months)
+	if n%2 == 0 {
+		return day
+	}
+	if day.Day <= 15 {
+		if last := daysIn(day.Year, day.Month); day.Day+15 > last {
+			return CivilDate{Year: day.Year, Month: day.Month, Day: last}
+		}
+		return CivilDate{Year: day.Year, Month: day.Month, Day: day.Day + 15}
+	}
+	next := CivilDate{Year: day.Year, Month: day.Month, Day: 1}.AddMonths(1)
+	return CivilDate{Year: next.Year, Month: next.Month, Day: day.Day - 15}
+}
+
+// SubscriptionDetector finds recurring charges in a user's history.  The zero value wants three
+// charges (two for annual ones) within 15% of each other and drops anything under 0.5
+// confidence, dates are in UTC unless Location says otherwise
+type SubscriptionDetector struct {
+	MinInstances    int            // charges needed before we call it recurring (3, annual needs 2)
+	AmountTolerance float64        // fraction an amount can stray from the typical one (0.15)
+	MinConfidence   float64        // drop candidates below this (0.5)
+	Location        *time.Location // user's zone for dates (UTC)
+}
+
+func (d SubscriptionDetector) withDefaults() SubscriptionDetector {
+	if d.MinInstances <= 0 {
+		d.MinInstances = 3
+	}
+	if d.AmountTolerance <= 0 {
+		d.AmountTolerance = 0.15
+	}
+	if d.MinConfidence <= 0 {
+		d.MinConfidence = 0.5
+	}
+	if d.Location == nil {
+		d.Location = time.UTC
+	}
+	return d
+}
+
+// merchantKey is what recurring charges from the same merchant have in common - plaid's merchant
+// name when there is one, otherwise the cleaned up descriptor
+func merchantKey(transaction CowTransaction) string {
+	for _, text := range []string{transaction.MerchantName, transaction.Name, transaction.OriginalDescription} {
+		if key := NormalizeMerchant(text).Key; key != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+type datedCharge struct {
+	day         CivilDate
+	amount      float64
+	transaction CowTransaction
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+	return (sorted[middle-1] + sorted[middle]) / 2
+}
+
+// chargesByMerchant groups settled outgoing charges per user and merchant, oldest first
+func chargesByMerchant(history []CowTransaction, loc *time.Location) map[string][]datedCharge {
+	groups := map[string][]datedCharge{}
+	for _, transaction := range history {
+		if transaction.Pending || transaction.Amount <= 0 {
+			continue
+		}
+		key := merchantKey(transaction)
+		day := transaction.EffectiveDate(loc)
+		if key == "" || day.IsZero() {
+			continue
+		}
+		groupKey := transaction.UID + "\x00" + key
+		groups[groupKey] = append(groups[groupKey], datedCharge{day: day, amount: transaction.Amount, transaction: transaction})
+	}
+	for _, charges := range groups {
+		sort.SliceStable(charges, func(i, j int) bool {
+			return charges[i].day.Before(charges[j].day)
+		})
+	}
+	return groups
+}
+
+// amountClusters splits one merchant's charges by amount.  A charge joins the first cluster
+// whose latest amount it is within tolerance of, so a price creeping up stays in one
+func amountClusters(charges []datedCharge, tolerance float64) [][]datedCharge {
+	var clusters [][]datedCharge
+	for _, charge := range charges {
+		placed := false
+		for i, cluster := range clusters {
+			latest := cluster[len(cluster)-1].amount
+			if math.Abs(charge.amount-latest) <= latest*tolerance {
+				clusters[i] = append(cluster, charge)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			clusters = append(clusters, []datedCharge{charge})
+		}
+	}
+	return clusters
+}
+
+// detectMerchant finds the subscriptions in one merchant's charges.  When at least two of its
+// amount clusters recur on their own the merchant has several plans, otherwise the charges are
+// taken together so a price increase or a bill that varies month to month is still one
+func (d SubscriptionDetector) detectMerchant(charges []datedCharge) []PossibleSubscriptions {
+	var plans []PossibleSubscriptions
+	for _, cluster := range amountClusters(charges, d.AmountTolerance) {
+		if found, ok := d.detectGroup(cluster); ok {
+			plans = append(plans, found)
+		}
+	}
+	if len(plans) >= 2 {
+		return plans
+	}
+	if found, ok := d.detectGroup(charges); ok {
+		return []PossibleSubscriptions{found}
+	}
+	return plans
+}
+
+// Detect returns the recurring charges in history, most confident first.  A merchant with
+// several plans comes back as one subscription per plan
+func (d SubscriptionDetector) Detect(history []CowTransaction) []PossibleSubscriptions {
+	d = d.withDefaults()
+	var out []PossibleSubscriptions
+	for _, charges := range chargesByMerchant(history, d.Location) {
+		out = append(out, d.detectMerchant(charges)...)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Confidence != out[j].Confidence {
+			return out[i].Confidence > out[j].Confidence
+		}
+		if out[i].MerchantKey != out[j].MerchantKey {
+			return out[i].MerchantKey < out[j].MerchantKey
+		}
+		return out[i].Amount < out[j].Amount
+	})
+	return out
+}
+
+func (d SubscriptionDetector) detectGroup(charges []datedCharge) (PossibleSubscriptions, bool) {
+	if len(charges) < 2 {
+		return PossibleSubscriptions{}, false
+	}
+	var intervals []float64
+	var amounts []float64
+	for i, charge := range charges {
+		amounts = append(amounts, charge.amount)
+		if i > 0 {
+			intervals = append(intervals, float64(charges[i-1].day.DaysUntil(charge.day)))
+		}
+	}
+	interval := median(intervals)
+	var spec periodSpec
+	for _, candidate := range periodSpecs {
+		if math.Abs(interval-candidate.days) <= candidate.tolerance {
+			spec = candidate
+			break
+		}
+	}
+	if spec.period == PeriodBiweekly || spec.period == PeriodSemimonthly {
+		spec = biweeklyOrSemimonthly(charges)
+	}
+	minInstances := d.MinInstances
+	if spec.period == PeriodAnnual && minInstances > 2 {
+		minInstances = 2
+	}
+	if spec.period == PeriodUnknown || len(charges) < minInstances {
+		return PossibleSubscriptions{}, false
+	}
+	onSchedule := 0
+	for _, gap := range intervals {
+		if math.Abs(gap-spec.days) <= spec.tolerance {
+			onSchedule++
+		}
+	}
+	typical := median(amounts)
+	steady := 0
+	for _, amount := range amounts {
+		if math.Abs(amount-typical) <= typical*d.AmountTolerance {
+			steady++
+		}
+	}
+	regularity := float64(onSchedule) / float64(len(intervals))
+	consistency := float64(steady) / float64(len(amounts))
+	history := math.Min(float64(len(charges))/6, 1)
+	confidence := 0.6*regularity + 0.3*consistency + 0.1*history
+	if confidence < d.MinConfidence {
+		return PossibleSubscriptions{}, false
+	}
+	last := charges[len(charges)-1].transaction
+	var lastIDs []string
+	for _, charge := range charges {
+		if charge.day == charges[len(charges)-1].day && charge.transaction.TransactionID != "" {
+			lastIDs = append(lastIDs, charge.transaction.TransactionID)
+		}
+	}
+	classified := Classify(last)
+	name := last.MerchantName
+	if name == "" {
+		name = NormalizeMerchant(last.Name).DisplayName
+	}
+	return PossibleSubscriptions{
+		Name:                name,
+		OriginalDescription: last.OriginalDescription,
+		Amount:              last.Amount,
+		MerchantName:        last.MerchantName,
+		UID:                 last.UID,
+		IsPhysicalLocation:  classified.PhysicalLocation,
+		FlatType:            classified.Description,
+		DetailedDescription: classified.DetailedDescription,
+		CowIcon:             XActionIconNeutral,
+		Instances:           len(charges),
+		MerchantKey:         merchantKey(last),
+		Period:              spec.period,
+		IntervalDays:        interval,
+		FirstCharge:         charges[0].day,
+		LastCharge:          charges[len(charges)-1].day,
+		LastChargeIDs:       lastIDs,
+		Confidence:          math.Round(confidence*1000) / 1000,
+	}, true
+}
+
+// biweeklyOrSemimonthly tells pay every other Friday from pay on the 1st and 15th.  The gaps
+// look alike, so it counts which schedule, stepped from the first charge, lands more of the
+// charges within two days - biweekly wins a tie
+func biweeklyOrSemimonthly(charges []datedCharge) periodSpec {
+	first := charges[0].day
+	biweekly, semimonthly := 0, 0
+	for _, charge := range charges {
+		days := first.DaysUntil(charge.day)
+		if math.Abs(float64(days)-14*math.Round(float64(days)/14)) <= 2 {
+			biweekly++
+		}
+		nearest := math.Round(float64(days) / 15.22)
+		for k := nearest - 1; k <= nearest+1; k++ {
+			if k >= 0 && math.Abs(float64(PeriodSemimonthly.Step(first, int(k)).DaysUntil(charge.day))) <= 2 {
+				semimonthly++
+				break
+			}
+		}
+	}
+	period := PeriodBiweekly
+	if semimonthly > biweekly {
+		period = PeriodSemimonthly
+	}
+	spec, _ := specFor(period)
+	return spec
+}
+
+// DetectIncome finds recurring deposits - paychecks mostly - the same way.  They come back as
+// subscriptions with a negative Amount, the way plaid signs money coming in
+func (d SubscriptionDetector) DetectIncome(history []CowTransaction) []PossibleSubscriptions {
+	var flipped []CowTransaction
+	for _, transaction := range history {
+		if transaction.Amount < 0 {
+			transaction.Amount = -transaction.Amount
+			flipped = append(flipped, transaction)
+		}
+	}
+	found := d.Detect(flipped)
+	for i := range found {
+		found[i].Amount = -found[i].Amount
+	}
+	return found
+}
+
+// DetectSubscriptions runs a default SubscriptionDetector
+func DetectSubscriptions(history []CowTransaction) []PossibleSubscriptions {
+	return SubscriptionDetector{}.Detect(history)
+}
+
+// DetectIncome runs a default SubscriptionDetector over deposits
+func DetectIncome(history []CowTransaction) []PossibleSubscriptions {
+	return SubscriptionDetector{}.DetectIncome(history)
+}
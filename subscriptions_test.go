@@ -0,0 +1,110 @@
+package spacecow_common
+
+import (
+	"testing"
+)
+
+// charges is n transactions from a merchant, the first on start and then every step
+func charges(t *testing.T, merchant string, amount float64, start string, n int, step func(CivilDate, int) CivilDate) []CowTransaction {
+	t.Helper()
+	first := civilDay(t, start)
+	var out []CowTransaction
+	for i := 0; i < n; i++ {
+		out = append(out, CowTransaction{
+			TransactionID: merchant + step(first, i).String(),
+			UID:           "u1",
+			MerchantName:  merchant,
+			Name:          merchant,
+			Amount:        amount,
+			Date:          step(first, i).String(),
+		})
+	}
+	return out
+}
+
+func monthly(from CivilDate, n int) CivilDate {
+	return from.AddMonths(n)
+}
+
+func TestSubscriptionPeriodStep(t *testing.T) {
+	from := civilDay(t, "2023-01-31")
+	if got := PeriodMonthly.Step(from, 2).String(); got != "2023-03-31" {
+		t.Errorf("monthly step %s", got)
+	}
+	if got := PeriodWeekly.Next(from).String(); got != "2023-02-07" {
+		t.Errorf("weekly next %s", got)
+	}
+	if got := PeriodUnknown.Next(from); got != from {
+		t.Errorf("unknown next %s", got)
+	}
+	if PeriodAnnual.String() != "annual" || PeriodUnknown.String() != "unknown" {
+		t.Error("period names")
+	}
+}
+
+func TestDetectSubscriptions(t *testing.T) {
+	var history []CowTransaction
+	history = append(history, charges(t, "Netflix", 15.49, "2023-01-15", 6, monthly)...)
+	history = append(history, charges(t, "Adobe", 239.88, "2021-03-02", 2, func(from CivilDate, n int) CivilDate {
+		return from.AddMonths(12 * n)
+	})...)
+	// one off shopping never makes a subscription
+	history = append(history, charges(t, "Target", 40, "2023-01-03", 3, func(from CivilDate, n int) CivilDate {
+		return from.AddDays(n * n * 11)
+	})...)
+	history = append(history, CowTransaction{UID: "u1", MerchantName: "Netflix", Amount: 15.49, Date: "2023-07-15", Pending: true})
+
+	found := DetectSubscriptions(history)
+	if len(found) != 2 {
+		t.Fatalf("found %+v", found)
+	}
+	netflix := found[0]
+	if netflix.Name != "Netflix" || netflix.Period != PeriodMonthly || netflix.Instances != 6 || netflix.Confidence != 1 {
+		t.Errorf("netflix %+v", netflix)
+	}
+	if netflix.FirstCharge.String() != "2023-01-15" || netflix.LastCharge.String() != "2023-06-15" {
+		t.Errorf("netflix dates %s %s", netflix.FirstCharge, netflix.LastCharge)
+	}
+	if found[1].Name != "Adobe" || found[1].Period != PeriodAnnual || found[1].Confidence >= netflix.Confidence {
+		t.Errorf("adobe %+v", found[1])
+	}
+}
+
+func TestDetectSubscriptionsAmountTolerance(t *testing.T) {
+	history := charges(t, "City Power", 80, "2023-01-05", 6, monthly)
+	history[2].Amount = 140
+	history[4].Amount = 20
+	strict := SubscriptionDetector{MinConfidence: 0.95}.Detect(history)
+	if len(strict) != 0 {
+		t.Errorf("strict detector kept %+v", strict)
+	}
+	loose := SubscriptionDetector{AmountTolerance: 0.9, MinConfidence: 0.95}.Detect(history)
+	if len(loose) != 1 {
+		t.Errorf("loose detector found %+v", loose)
+	}
+}
+
+func TestDetectSubscriptionsSplitsPlans(t *testing.T) {
+	history := append(charges(t, "Apple", 2.99, "2023-01-03", 6, monthly), charges(t, "Apple", 10.99, "2023-01-20", 6, monthly)...)
+	found := DetectSubscriptions(history)
+	if len(found) != 2 || found[0].Amount != 2.99 || found[1].Amount != 10.99 {
+		t.Fatalf("found %+v", found)
+	}
+	for _, plan := range found {
+		if plan.Period != PeriodMonthly || plan.Instances != 6 || plan.MerchantKey != found[0].MerchantKey {
+			t.Errorf("plan %+v", plan)
+		}
+	}
+	// a price increase is still the one plan
+	increase := charges(t, "Apple", 2.99, "2023-01-03", 6, monthly)
+	increase[5].Amount = 3.99
+	if found := DetectSubscriptions(increase); len(found) != 1 || found[0].Amount != 3.99 {
+		t.Errorf("price increase %+v", found)
+	}
+}
+
+func TestDetectSubscriptionsSkipsDeposits(t *testing.T) {
+	if found := DetectSubscriptions(charges(t, "ACME PAYROLL", -2500, "2023-01-31", 6, monthly)); len(found) != 0 {
+		t.Errorf("deposits detected as subscriptions %+v", found)
+	}
+}
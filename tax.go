@@ -0,0 +1,393 @@
+package spacecow_common
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed data/tax.csv
+var embeddedTaxRules []byte
+
+var defaultTaxRules = mustParseTaxRules(embeddedTaxRules)
+
+var taxRuleHeader = []string{"match", "value", "tag"}
+
+// TaxTag is why a transaction might matter at tax time
+type TaxTag int
+
+const (
+	TaxNone TaxTag = iota
+	TaxCharitable
+	TaxMedical
+	TaxBusiness
+	TaxEducation
+	TaxPayment
+	TaxRefund
+)
+
+var taxTagNames = enumNames[TaxTag]{kind: "tax tag", names: map[TaxTag]string{
+	TaxNone:       "none",
+	TaxCharitable: "charitable",
+	TaxMedical:    "medical",
+	TaxBusiness:   "business",
+	TaxEducation:  "education",
+	TaxPayment:    "tax_payment",
+	TaxRefund:     "tax_refund",
+}}
+
+func (tag TaxTag) String() string {
+	return taxTagNames.name(tag)
+}
+
+// ParseTaxTag reads a tag name written by String
+func ParseTaxTag(name string) (TaxTag, error) {
+	return taxTagNames.parse(name)
+}
+
+// MarshalJSON writes the same names as the tag column of data/tax.csv
+func (tag TaxTag) MarshalJSON() ([]byte, error) {
+	return taxTagNames.marshalJSON(tag)
+}
+
+// UnmarshalJSON reads the tax.csv names back
+func (tag *TaxTag) UnmarshalJSON(raw []byte) error {
+	return taxTagNames.unmarshalJSON(raw, tag)
+}
+
+// TaxItem is one tax relevant transaction
+type TaxItem struct {
+	TransactionID string    `json:"transaction_id" bson:"transactionId"`
+	UID           string    `json:"uid" bson:"uid"`
+	Date          CivilDate `json:"date" bson:"date"`
+	Merchant      string    `json:"merchant" bson:"merchant"`
+	Tag           TaxTag    `json:"tag" bson:"tag"`
+	Amount        float64   `json:"amount" bson:"amount"` // tax refunds are positive, a refunded deductible purchase is negative
+	Currency      string    `json:"currency" bson:"currency"`
+	Source        string    `json:"source" bson:"source"` // merchant, category or account
+	Matched       string    `json:"matched" bson:"matched"`
+}
+
+type taxMerchant struct {
+	words string
+	tag   TaxTag
+}
+
+// TaxRules maps categories and merchants onto tax tags
+type TaxRules struct {
+	Version    string
+	categories map[string]TaxTag
+	merchants  []taxMerchant
+}
+
+// DefaultTaxRules are the rules embedded in this package
+func DefaultTaxRules() *TaxRules {
+	return defaultTaxRules
+}
+
+// ParseTaxRules reads a match,value,tag csv
+func ParseTaxRules(r io.Reader) (*TaxRules, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := newDataFileReader(raw, taxRuleHeader)
+	if err != nil {
+		return nil, fmt.Errorf("tax rules %w", err)
+	}
+	rules := &TaxRules{Version: dataFileVersion(raw), categories: map[string]TaxTag{}}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		tag, err := ParseTaxTag(record[2])
+		if err != nil {
+			return nil, fmt.Errorf("tax rules %s: %w", record[1], err)
+		}
+		value := strings.TrimSpace(record[1])
+		switch strings.TrimSpace(record[0]) {
+		case "category":
+			if _, ok := DefaultTaxonomy().Lookup(value); !ok {
+				return nil, fmt.Errorf("tax rules: unknown category %s", value)
+			}
+			rules.categories[value] = tag
+		case "merchant":
+			rules.merchants = append(rules.merchants, taxMerchant{words: normalizeMerchantText(value), tag: tag})
+		default:
+			return nil, fmt.Errorf("tax rules: unknown match %q", record[0])
+		}
+	}
+	return rules, nil
+}
+
+// OpenTaxRules loads rules from disk
+func OpenTaxRules(path string) (*TaxRules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseTaxRules(f)
+}
+
+func mustParseTaxRules(raw []byte) *TaxRules {
+	rules, err := ParseTaxRules(bytes.NewReader(raw))
+	if err != nil {
+		panic("embedded tax rules: " + err.Error())
+	}
+	return rules
+}
+
+// transactionCategoryID is the stored introspection's category id, or a fresh classification's
+func transactionCategoryID(transaction CowTransaction) string {
+	if found, ok := DefaultTaxonomy().LookupPath(transaction.DetailedDescription); ok {
+		return found.ID
+	}
+	return Classify(transaction).ID
+}
+
+// category finds the tag for a category id or the closest ancestor that has one
+func (rules *TaxRules) category(categoryID string) (TaxTag, string) {
+	if tag, ok := rules.categories[categoryID]; ok {
+		return tag, categoryID
+	}
+	ancestors := DefaultTaxonomy().Ancestors(categoryID)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if tag, ok := rules.categories[ancestors[i].ID]; ok {
+			return tag, ancestors[i].ID
+		}
+	}
+	return TaxNone, ""
+}
+
+// merchant finds the longest merchant rule in the transaction's names
+func (rules *TaxRules) merchant(transaction CowTransaction) (TaxTag, string) {
+	texts := []string{
+		NormalizeMerchant(transaction.MerchantName).Key,
+		NormalizeMerchant(transaction.Name).Key,
+		normalizeMerchantText(transaction.Name + " " + transaction.OriginalDescription),
+	}
+	best := taxMerchant{}
+	for _, rule := range rules.merchants {
+		if len(rule.words) <= len(best.words) {
+			continue
+		}
+		for _, text := range texts {
+			if containsWords(text, rule.words) {
+				best = rule
+				break
+			}
+		}
+	}
+	return best.tag, best.words
+}
+
+// TaxTagger finds tax relevant transactions.  Charges on a business account are business
+// unless something more specific matches
+type TaxTagger struct {
+	Rules            *TaxRules       // DefaultTaxRules when nil
+	BusinessAccounts map[string]bool // account ids
+	Location         *time.Location  // user's zone for dates (UTC)
+}
+
+// Tag finds the tax tag of one transaction - merchant rules first, then the category, then
+// the account.  Tax payments have to go out and tax refunds come in, so a merchant tag facing
+// the wrong way gives the category a go - the IRS sends refunds too.  Money coming back on a
+// deductible tag is a refund of the purchase and gets a negative Amount the summaries net
+// against it.  Pending transactions are left alone until they settle
+func (t TaxTagger) Tag(transaction CowTransaction) (TaxItem, bool) {
+	if transaction.Pending || transaction.Amount == 0 {
+		return TaxItem{}, false
+	}
+	rules := t.Rules
+	if rules == nil {
+		rules = DefaultTaxRules()
+	}
+	incoming := transaction.Amount < 0
+	fits := func(tag TaxTag) bool {
+		switch tag {
+		case TaxPayment:
+			return !incoming
+		case TaxRefund:
+			return incoming
+		}
+		return true
+	}
+	tag, matched := rules.merchant(transaction)
+	source := "merchant"
+	if tag == TaxNone || !fits(tag) {
+		tag, matched = rules.category(transactionCategoryID(transaction))
+		source = "category"
+	}
+	// money coming into a business account is income, not a refund
+	if tag == TaxNone && !incoming && t.BusinessAccounts[transaction.AccountID] {
+		tag, matched, source = TaxBusiness, transaction.AccountID, "account"
+	}
+	if tag == TaxNone || !fits(tag) {
+		return TaxItem{}, false
+	}
+	amount := transaction.Amount
+	if tag == TaxRefund {
+		amount = -amount
+	}
+	merchant := transaction.MerchantName
+	if merchant == "" {
+		merchant = NormalizeMerchant(transaction.Name).DisplayName
+	}
+	return TaxItem{
+		TransactionID: transaction.TransactionID,
+		UID:           transaction.UID,
+		Date:          transaction.EffectiveDate(t.Location),
+		Merchant:      merchant,
+		Tag:           tag,
+		Amount:        amount,
+		Currency:      transaction.CurrencyCode(),
+		Source:        source,
+		Matched:       matched,
+	}, true
+}
+
+// TaxSummaryLine is the year's total for one tag
+type TaxSummaryLine struct {
+	Tag   TaxTag  `json:"tag" bson:"tag"`
+	Total float64 `json:"total" bson:"total"`
+	Count int     `json:"count" bson:"count"`
+}
+
+// TaxYearSummary is everything tax relevant for one user in one calendar year and currency
+type TaxYearSummary struct {
+	UID      string           `json:"uid" bson:"uid"`
+	Year     int              `json:"year" bson:"year"`
+	Currency string           `json:"currency" bson:"currency"`
+	Lines    []TaxSummaryLine `json:"lines" bson:"lines"` // in tag order, only tags with items
+	Items    []TaxItem        `json:"items" bson:"items"` // oldest first
+}
+
+// Line is the total for one tag, zero when there's nothing
+func (s TaxYearSummary) Line(tag TaxTag) TaxSummaryLine {
+	for _, line := range s.Lines {
+		if line.Tag == tag {
+			return line
+		}
+	}
+	return TaxSummaryLine{Tag: tag}
+}
+
+// Summaries groups the tagged transactions into one summary per user, year and currency,
+// ordered by UID, year then currency.  Totals are added up as Money so they come out exact,
+// with refunds of deductible purchases taken off
+func (t TaxTagger) Summaries(transactions []CowTransaction) []TaxYearSummary {
+	byKey := map[string]*TaxYearSummary{}
+	var keys []string
+	for _, transaction := range transactions {
+		item, ok := t.Tag(transaction)
+		if !ok || item.Date.IsZero() {
+			continue
+		}
+		key := item.UID + "\x00" + fmt.Sprintf("%04d", item.Date.Year) + "\x00" + item.Currency
+		summary, seen := byKey[key]
+		if !seen {
+			summary = &TaxYearSummary{UID: item.UID, Year: item.Date.Year, Currency: item.Currency}
+			byKey[key] = summary
+			keys = append(keys, key)
+		}
+		summary.Items = append(summary.Items, item)
+	}
+	sort.Strings(keys)
+	out := make([]TaxYearSummary, 0, len(keys))
+	for _, key := range keys {
+		summary := byKey[key]
+		sort.SliceStable(summary.Items, func(i, j int) bool {
+			return summary.Items[i].Date.Before(summary.Items[j].Date)
+		})
+		totals := map[TaxTag]Money{}
+		counts := map[TaxTag]int{}
+		for _, item := range summary.Items {
+			totals[item.Tag], _ = totals[item.Tag].Add(MoneyFromFloat(item.Amount, item.Currency, RoundHalfEven))
+			counts[item.Tag]++
+		}
+		for tag := TaxCharitable; tag <= TaxRefund; tag++ {
+			if counts[tag] > 0 {
+				summary.Lines = append(summary.Lines, TaxSummaryLine{Tag: tag, Total: totals[tag].Float64(), Count: counts[tag]})
+			}
+		}
+		out = append(out, *summary)
+	}
+	return out
+}
+
+// WriteCSV exports the summary's items for an accountant or tax software
+func (s TaxYearSummary) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"date", "transaction_id", "merchant", "tag", "amount", "currency"}); err != nil {
+		return err
+	}
+	for _, item := range s.Items {
+		amount := MoneyFromFloat(item.Amount, item.Currency, RoundHalfEven).Decimal()
+		record := []string{item.Date.String(), item.TransactionID, item.Merchant, item.Tag.String(), amount, item.Currency}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// TaxDeductionRate is the bracket tax tips estimate savings with
+const TaxDeductionRate = 0.22
+
+// Tips are the CowTipsTax tips for the summary - deductible totals worth keeping receipts for
+func (s TaxYearSummary) Tips() []CowTips {
+	var out []CowTips
+	for _, line := range s.Lines {
+		if line.Total <= 0 {
+			continue // refunded in full
+		}
+		savings := 0
+		switch line.Tag {
+		case TaxCharitable, TaxBusiness:
+			savings = int(line.Total * TaxDeductionRate)
+		case TaxMedical, TaxEducation:
+		default:
+			continue
+		}
+		out = append(out, CowTips{
+			Type:       CowTipsTax,
+			Savings:    savings,
+			MessageKey: "tip.tax." + line.Tag.String(),
+			MessageArgs: map[string]string{
+				"total": formatAmount(line.Total),
+				"count": strconv.Itoa(line.Count),
+				"year":  strconv.Itoa(s.Year),
+			},
+		})
+	}
+	return out
+}
+
+// taxYearRule is the tip engine rule for the year AsOf falls in
+func taxYearRule(input TipInput) []CowTips {
+	for _, summary := range (TaxTagger{Location: input.Location}).Summaries(input.Transactions) {
+		if summary.Year == input.AsOf.Year {
+			return summary.Tips()
+		}
+	}
+	return nil
+}
+
+// TaxYearSummaries runs a default TaxTagger
+func TaxYearSummaries(transactions []CowTransaction) []TaxYearSummary {
+	return TaxTagger{}.Summaries(transactions)
+}
@@ -0,0 +1,147 @@
+package spacecow_common
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTaxTag(t *testing.T) {
+	cases := []struct {
+		name        string
+		transaction CowTransaction
+		want        TaxTag
+		source      string
+	}{
+		{"irs payment", CowTransaction{Name: "IRS USATAXPYMT", Amount: 2500}, TaxPayment, "merchant"},
+		{"irs refund", CowTransaction{Name: "IRS TREAS 310 TAX REF", CategoryID: "20001000", Amount: -1200}, TaxRefund, "category"},
+		{"state refund", CowTransaction{Name: "FRANCHISE TAX BOARD", CategoryID: "20001000", Amount: -300}, TaxRefund, "category"},
+		{"charity", CowTransaction{Name: "AMERICAN RED CROSS DONATION", Amount: 50}, TaxCharitable, "merchant"},
+		{"pharmacy", CowTransaction{Name: "CVS", CategoryID: "19043000", Amount: 12}, TaxMedical, "category"},
+		{"doctor under medical", CowTransaction{Name: "DR SMITH", CategoryID: "14001010", Amount: 120}, TaxMedical, "category"},
+		{"tax prep fee", CowTransaction{Name: "H&R BLOCK", CategoryID: "18020001", Amount: 89}, TaxNone, ""},
+		{"charity refund", CowTransaction{Name: "UNICEF", Amount: -50}, TaxCharitable, "merchant"},
+		{"pharmacy refund", CowTransaction{Name: "CVS", CategoryID: "19043000", Amount: -12}, TaxMedical, "category"},
+		{"irs payment coming in", CowTransaction{Name: "IRS USATAXPYMT", Amount: -2500}, TaxNone, ""},
+		{"groceries", CowTransaction{Name: "SAFEWAY", CategoryID: "19047000", Amount: 80}, TaxNone, ""},
+		{"pending", CowTransaction{Name: "UNICEF", Amount: 50, Pending: true}, TaxNone, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			item, ok := TaxTagger{}.Tag(c.transaction)
+			if ok != (c.want != TaxNone) || item.Tag != c.want || item.Source != c.source {
+				t.Errorf("got %v %+v", ok, item)
+			}
+			// only a refunded purchase comes out negative
+			if refunded := c.transaction.Amount < 0 && c.want != TaxRefund; ok && (item.Amount < 0) != refunded {
+				t.Errorf("amount %v", item.Amount)
+			}
+		})
+	}
+}
+
+func TestTaxTagBusinessAccounts(t *testing.T) {
+	tagger := TaxTagger{BusinessAccounts: map[string]bool{"biz": true}}
+	item, ok := tagger.Tag(CowTransaction{AccountID: "biz", Name: "STAPLES", CategoryID: "19000000", Amount: 40})
+	if !ok || item.Tag != TaxBusiness || item.Source != "account" || item.Matched != "biz" {
+		t.Errorf("business %+v", item)
+	}
+	item, _ = tagger.Tag(CowTransaction{AccountID: "biz", Name: "UNICEF", Amount: 40})
+	if item.Tag != TaxCharitable {
+		t.Errorf("charity on the business card %+v", item)
+	}
+	if item, ok := tagger.Tag(CowTransaction{AccountID: "biz", Name: "CLIENT INVOICE 42", Amount: -400}); ok {
+		t.Errorf("business income tagged %+v", item)
+	}
+}
+
+func TestParseTaxRules(t *testing.T) {
+	rules, err := ParseTaxRules(strings.NewReader("# version: 9\nmatch,value,tag\ncategory,19043000,Medical\nmerchant,Acme Clinic,medical\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item, ok := (TaxTagger{Rules: rules}).Tag(CowTransaction{Name: "ACME CLINIC", Amount: 10}); rules.Version != "9" || !ok || item.Matched != "acme clinic" {
+		t.Errorf("%s %+v", rules.Version, item)
+	}
+	bad := []string{
+		"match,value,tag\ncategory,99999999,medical\n",
+		"match,value,tag\ncategory,19043000,deductible\n",
+		"match,value,tag\naccount,x,medical\n",
+	}
+	for _, raw := range bad {
+		if _, err := ParseTaxRules(strings.NewReader(raw)); err == nil {
+			t.Errorf("accepted %q", raw)
+		}
+	}
+}
+
+func TestTaxYearSummaries(t *testing.T) {
+	transactions := []CowTransaction{
+		{TransactionID: "a", UID: "u1", Name: "UNICEF", Amount: 0.1, Date: "2023-03-01"},
+		{TransactionID: "b", UID: "u1", Name: "UNICEF", Amount: 0.2, Date: "2023-01-01"},
+		{TransactionID: "c", UID: "u1", Name: "UNICEF", Amount: 25, Date: "2022-12-31"},
+		{TransactionID: "d", UID: "u1", Name: "UNICEF", Amount: 10, IsoCurrencyCode: "EUR", Date: "2023-02-01"},
+		{TransactionID: "e", UID: "u1", Name: "IRS TREAS 310 TAX REF", CategoryID: "20001000", Amount: -1200, Date: "2023-04-01"},
+		{TransactionID: "f", UID: "u1", Name: "UNICEF", Amount: -0.1, Date: "2023-03-05"},
+	}
+	summaries := TaxYearSummaries(transactions)
+	if len(summaries) != 3 {
+		t.Fatalf("summaries %+v", summaries)
+	}
+	if summaries[0].Year != 2022 || summaries[1].Currency != "" || summaries[2].Currency != "EUR" {
+		t.Errorf("order %+v", summaries)
+	}
+	dollars := summaries[1]
+	if line := dollars.Line(TaxCharitable); line.Total != 0.2 || line.Count != 3 {
+		t.Errorf("charitable %+v", line)
+	}
+	if line := dollars.Line(TaxRefund); line.Total != 1200 {
+		t.Errorf("refund %+v", line)
+	}
+	if dollars.Items[0].TransactionID != "b" {
+		t.Errorf("items not oldest first %+v", dollars.Items)
+	}
+	var out bytes.Buffer
+	if err := dollars.WriteCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 || lines[0] != "date,transaction_id,merchant,tag,amount,currency" || lines[1] != "2023-01-01,b,Unicef,charitable,0.20," || lines[3] != "2023-03-05,f,Unicef,charitable,-0.10," {
+		t.Errorf("csv %q", out.String())
+	}
+	out.Reset()
+	if err := summaries[2].WriteCSV(&out); err != nil || !strings.HasSuffix(out.String(), ",charitable,10.00,EUR\n") {
+		t.Errorf("euro csv %q %v", out.String(), err)
+	}
+}
+
+func TestTaxYearRule(t *testing.T) {
+	transactions := []CowTransaction{
+		{UID: "u1", Name: "UNICEF", Amount: 500, Date: "2022-12-01"},
+		{UID: "u1", Name: "UNICEF", Amount: 1000, Date: "2023-03-01"},
+		{UID: "u1", Name: "CVS", CategoryID: "19043000", Amount: 40, Date: "2023-03-02"},
+		{UID: "u1", Name: "IRS USATAXPYMT", Amount: 2500, Date: "2023-04-15"},
+	}
+	tips := taxYearRule(TipInput{Transactions: transactions, AsOf: civilDay(t, "2023-06-01")})
+	if len(tips) != 2 {
+		t.Fatalf("tips %+v", tips)
+	}
+	if tips[0].MessageKey != "tip.tax.charitable" || tips[0].Savings != 220 || tips[0].MessageArgs["year"] != "2023" {
+		t.Errorf("charitable %+v", tips[0])
+	}
+	if tips[1].MessageKey != "tip.tax.medical" || tips[1].Savings != 0 {
+		t.Errorf("medical %+v", tips[1])
+	}
+}
+
+func TestTaxTagJSON(t *testing.T) {
+	raw, err := json.Marshal(TaxItem{Tag: TaxRefund})
+	if err != nil || !strings.Contains(string(raw), `"tag":"tax_refund"`) {
+		t.Fatalf("%s %v", raw, err)
+	}
+	var back TaxItem
+	if err := json.Unmarshal(raw, &back); err != nil || back.Tag != TaxRefund {
+		t.Errorf("%v %v", back.Tag, err)
+	}
+}
@@ -0,0 +1,201 @@
+package spacecow_common
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/taxonomy.csv
+var embeddedTaxonomy []byte
+
+var defaultTaxonomy = mustParseTaxonomy(embeddedTaxonomy)
+
+var taxonomyHeader = []string{"id", "physical_location", "transaction_type", "description", "detailed_description"}
+
+// xaction type names as they appear in the taxonomy file
+var xactionTypeNames = enumNames[XactionType]{kind: "transaction type", names: map[XactionType]string{
+	XactionPayment:        "payment",
+	XactionCharge:         "charge",
+	XactionCredit:         "credit",
+	XactionInterestCharge: "interest_charge",
+	XactionLateFee:        "late_fee",
+}}
+
+func (x XactionType) String() string {
+	return xactionTypeNames.name(x)
+}
+
+// MarshalJSON writes the taxonomy file name, "charge" or "late_fee"
+func (x XactionType) MarshalJSON() ([]byte, error) {
+	return xactionTypeNames.marshalJSON(x)
+}
+
+// UnmarshalJSON reads a name, or the plain number older documents have
+func (x *XactionType) UnmarshalJSON(raw []byte) error {
+	var number int
+	if err := json.Unmarshal(raw, &number); err == nil {
+		*x = XactionType(number)
+		return nil
+	}
+	return xactionTypeNames.unmarshalJSON(raw, x)
+}
+
+// Taxonomy is a loaded set of TransactionMap entries keyed by category id
+type Taxonomy struct {
+	Version string
+	entries []TransactionMap
+	byID    map[string]int
+	byPath  map[string]int
+	parent  []int
+	kids    [][]int
+}
+
+// DefaultTaxonomy is the taxonomy embedded in this package
+func DefaultTaxonomy() *Taxonomy {
+	return defaultTaxonomy
+}
+
+// ParseTaxonomy reads a taxonomy csv - lines starting with # are comments and
+// a "# version: x" comment sets the version
+func ParseTaxonomy(r io.Reader) (*Taxonomy, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	t := &Taxonomy{Version: dataFileVersion(raw), byID: map[string]int{}, byPath: map[string]int{}}
+	reader, err := newDataFileReader(raw, taxonomyHeader)
+	if err != nil {
+		return nil, fmt.Errorf("taxonomy %w", err)
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entry, err := parseTaxonomyRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := t.byID[entry.ID]; dup {
+			return nil, fmt.Errorf("taxonomy: duplicate id %s", entry.ID)
+		}
+		if _, dup := t.byPath[entry.DetailedDescription]; dup {
+			return nil, fmt.Errorf("taxonomy: duplicate path %q", entry.DetailedDescription)
+		}
+		t.byID[entry.ID] = len(t.entries)
+		t.byPath[entry.DetailedDescription] = len(t.entries)
+		t.entries = append(t.entries, entry)
+	}
+	t.linkHierarchy()
+	return t, nil
+}
+
+// dataFileVersion pulls the "# version: x" comment out of one of our data files
+func dataFileVersion(raw []byte) string {
+	for _, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimSpace(line)
+		comment := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if strings.HasPrefix(line, "#") && strings.HasPrefix(comment, "version:") {
+			return strings.TrimSpace(strings.TrimPrefix(comment, "version:"))
+		}
+	}
+	return ""
+}
+
+// newDataFileReader skips # comments and checks the header row of one of our data files
+func newDataFileReader(raw []byte, columns []string) (*csv.Reader, error) {
+	reader := csv.NewReader(bytes.NewReader(raw))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = len(columns)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	for i, name := range columns {
+		if header[i] != name {
+			return nil, fmt.Errorf("header: expected column %q, got %q", name, header[i])
+		}
+	}
+	return reader, nil
+}
+
+func parseTaxonomyRecord(record []string) (TransactionMap, error) {
+	physical, err := strconv.ParseBool(record[1])
+	if err != nil {
+		return TransactionMap{}, fmt.Errorf("taxonomy %s: physical_location: %w", record[0], err)
+	}
+	xactionType, err := ParseXactionType(record[2])
+	if err != nil {
+		return TransactionMap{}, fmt.Errorf("taxonomy %s: %w", record[0], err)
+	}
+	return TransactionMap{
+		ID:                  record[0],
+		PhysicalLocation:    physical,
+		TransactionType:     xactionType,
+		Description:         record[3],
+		DetailedDescription: record[4],
+	}, nil
+}
+
+func mustParseTaxonomy(raw []byte) *Taxonomy {
+	t, err := ParseTaxonomy(bytes.NewReader(raw))
+	if err != nil {
+		panic("embedded taxonomy: " + err.Error())
+	}
+	return t
+}
+
+// ParseXactionType turns a taxonomy file name like "charge" into an XactionType
+func ParseXactionType(name string) (XactionType, error) {
+	return xactionTypeNames.parse(name)
+}
+
+// Lookup finds the mapping for a category id
+func (t *Taxonomy) Lookup(categoryID string) (TransactionMap, bool) {
+	i, ok := t.byID[categoryID]
+	if !ok {
+		return TransactionMap{}, false
+	}
+	return t.entries[i], true
+}
+
+// Entries returns every mapping in file order
+func (t *Taxonomy) Entries() []TransactionMap {
+	out := make([]TransactionMap, len(t.entries))
+	copy(out, t.entries)
+	return out
+}
+
+// WriteTo writes the taxonomy back out in the same csv layout it is read from
+func (t *Taxonomy) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	if t.Version != "" {
+		buf.WriteString("# version: " + t.Version + "\n")
+	}
+	out := csv.NewWriter(&buf)
+	_ = out.Write(taxonomyHeader)
+	for _, entry := range t.entries {
+		_ = out.Write([]string{
+			entry.ID,
+			strconv.FormatBool(entry.PhysicalLocation),
+			entry.TransactionType.String(),
+			entry.Description,
+			entry.DetailedDescription,
+		})
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return 0, err
+	}
+	return buf.WriteTo(w)
+}
@@ -0,0 +1,135 @@
+package spacecow_common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+)
+
+// TaxonomyChange is everything that happened to one category id between two taxonomies
+type TaxonomyChange struct {
+	ID                      string         `json:"id" bson:"id"`
+	Old                     TransactionMap `json:"old" bson:"old"`
+	New                     TransactionMap `json:"new" bson:"new"`
+	Added                   bool           `json:"added" bson:"added"`
+	Removed                 bool           `json:"removed" bson:"removed"`
+	Renamed                 bool           `json:"renamed" bson:"renamed"` // Description or DetailedDescription
+	PhysicalLocationChanged bool           `json:"physical_location_changed" bson:"physicalLocationChanged"`
+	TransactionTypeChanged  bool           `json:"transaction_type_changed" bson:"transactionTypeChanged"`
+}
+
+// TaxonomyDiff lists the changed category ids between two taxonomies, sorted by id
+type TaxonomyDiff struct {
+	From    string           `json:"from" bson:"from"`
+	To      string           `json:"to" bson:"to"`
+	Changes []TaxonomyChange `json:"changes" bson:"changes"`
+}
+
+// TransactionMigration is a stored transaction whose introspected fields moved
+type TransactionMigration struct {
+	TransactionID string `json:"transaction_id" bson:"transactionId"`
+	UID           string `json:"uid" bson:"uid"`
+	// before and after for each introspected field
+	OldDetailedDescription string      `json:"old_detailed_description" bson:"oldDetailedDescription"`
+	NewDetailedDescription string      `json:"new_detailed_description" bson:"newDetailedDescription"`
+	OldSCType              XactionType `json:"old_sc_type" bson:"oldSCType"`
+	NewSCType              XactionType `json:"new_sc_type" bson:"newSCType"`
+	OldIsPhysicalLocation  bool        `json:"old_is_physical_location" bson:"oldIsPhysicalLocation"`
+	NewIsPhysicalLocation  bool        `json:"new_is_physical_location" bson:"newIsPhysicalLocation"`
+	OldTaxonomyVersion     string      `json:"old_taxonomy_version" bson:"oldTaxonomyVersion"`
+}
+
+// MigrationReport sums up a MigrateTransactions batch
+type MigrationReport struct {
+	TaxonomyVersion string                 `json:"taxonomy_version" bson:"taxonomyVersion"`
+	Checked         int                    `json:"checked" bson:"checked"`
+	Restamped       int                    `json:"restamped" bson:"restamped"` // only the version changed
+	Changed         []TransactionMigration `json:"changed" bson:"changed"`
+}
+
+// Fingerprint is a hash of the taxonomy contents - two files with the same entries in the same
+// order share a fingerprint whatever their version comment says
+func (t *Taxonomy) Fingerprint() string {
+	hash := sha256.New()
+	entriesOnly := *t
+	entriesOnly.Version = ""
+	_, _ = entriesOnly.WriteTo(hash)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// DiffTaxonomies compares two taxonomies by category id
+func DiffTaxonomies(from *Taxonomy, to *Taxonomy) TaxonomyDiff {
+	diff := TaxonomyDiff{From: from.Version, To: to.Version}
+	for _, old := range from.entries {
+		current, ok := to.Lookup(old.ID)
+		if !ok {
+			diff.Changes = append(diff.Changes, TaxonomyChange{ID: old.ID, Old: old, Removed: true})
+			continue
+		}
+		change := TaxonomyChange{
+			ID:                      old.ID,
+			Old:                     old,
+			New:                     current,
+			Renamed:                 old.Description != current.Description || old.DetailedDescription != current.DetailedDescription,
+			PhysicalLocationChanged: old.PhysicalLocation != current.PhysicalLocation,
+			TransactionTypeChanged:  old.TransactionType != current.TransactionType,
+		}
+		if change.Renamed || change.PhysicalLocationChanged || change.TransactionTypeChanged {
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+	for _, current := range to.entries {
+		if _, ok := from.Lookup(current.ID); !ok {
+			diff.Changes = append(diff.Changes, TaxonomyChange{ID: current.ID, New: current, Added: true})
+		}
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].ID < diff.Changes[j].ID
+	})
+	return diff
+}
+
+// Introspect fills in the fields we compute ourselves instead of trusting plaid
+func (transaction *CowTransaction) Introspect(classifier *Classifier) Classification {
+	if classifier == nil {
+		classifier = &Classifier{}
+	}
+	result := classifier.Explain(*transaction)
+	transaction.IsPhysicalLocation = result.Map.PhysicalLocation
+	transaction.SCType = result.Map.TransactionType
+	transaction.DetailedDescription = result.Map.DetailedDescription
+	transaction.TaxonomyVersion = result.TaxonomyVersion
+	return result
+}
+
+// MigrateTransactions re-introspects a batch of stored transactions in place against the current
+// taxonomy and reports which ones moved.  A nil classifier uses the default chain
+func MigrateTransactions(transactions []CowTransaction, classifier *Classifier) MigrationReport {
+	report := MigrationReport{TaxonomyVersion: DefaultTaxonomy().Version}
+	for i := range transactions {
+		transaction := &transactions[i]
+		before := *transaction
+		transaction.Introspect(classifier)
+		report.Checked++
+		if before.DetailedDescription == transaction.DetailedDescription &&
+			before.SCType == transaction.SCType &&
+			before.IsPhysicalLocation == transaction.IsPhysicalLocation {
+			if before.TaxonomyVersion != transaction.TaxonomyVersion {
+				report.Restamped++
+			}
+			continue
+		}
+		report.Changed = append(report.Changed, TransactionMigration{
+			TransactionID:          transaction.TransactionID,
+			UID:                    transaction.UID,
+			OldDetailedDescription: before.DetailedDescription,
+			NewDetailedDescription: transaction.DetailedDescription,
+			OldSCType:              before.SCType,
+			NewSCType:              transaction.SCType,
+			OldIsPhysicalLocation:  before.IsPhysicalLocation,
+			NewIsPhysicalLocation:  transaction.IsPhysicalLocation,
+			OldTaxonomyVersion:     before.TaxonomyVersion,
+		})
+	}
+	return report
+}
@@ -0,0 +1,66 @@
+package spacecow_common
+
+import (
+	"strings"
+	"testing"
+)
+
+func taxonomyOf(t *testing.T, version string, rows ...string) *Taxonomy {
+	t.Helper()
+	taxonomy, err := ParseTaxonomy(strings.NewReader("# version: " + version + "\n" + strings.Join(taxonomyHeader, ",") + "\n" + strings.Join(rows, "\n") + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return taxonomy
+}
+
+func TestDiffTaxonomies(t *testing.T) {
+	from := taxonomyOf(t, "1", "1,true,charge,a,a", "2,true,charge,b,b", "3,false,charge,c,c", "4,false,charge,d,d")
+	to := taxonomyOf(t, "2", "1,true,charge,a,a", "2,true,charge,b,bee", "3,true,credit,c,c", "5,false,charge,e,e")
+	diff := DiffTaxonomies(from, to)
+	if diff.From != "1" || diff.To != "2" || len(diff.Changes) != 4 {
+		t.Fatalf("got %+v", diff)
+	}
+	renamed, moved, removed, added := diff.Changes[0], diff.Changes[1], diff.Changes[2], diff.Changes[3]
+	if renamed.ID != "2" || !renamed.Renamed || renamed.PhysicalLocationChanged {
+		t.Errorf("renamed %+v", renamed)
+	}
+	if moved.ID != "3" || moved.Renamed || !moved.PhysicalLocationChanged || !moved.TransactionTypeChanged {
+		t.Errorf("moved %+v", moved)
+	}
+	if removed.ID != "4" || !removed.Removed {
+		t.Errorf("removed %+v", removed)
+	}
+	if added.ID != "5" || !added.Added {
+		t.Errorf("added %+v", added)
+	}
+}
+
+func TestFingerprintIgnoresVersion(t *testing.T) {
+	a := taxonomyOf(t, "1", "1,true,charge,a,a")
+	b := taxonomyOf(t, "2", "1,true,charge,a,a")
+	c := taxonomyOf(t, "1", "1,false,charge,a,a")
+	if a.Fingerprint() != b.Fingerprint() || a.Fingerprint() == c.Fingerprint() {
+		t.Error("fingerprints should only follow the entries")
+	}
+}
+
+func TestMigrateTransactions(t *testing.T) {
+	current := CowTransaction{TransactionID: "same", CategoryID: "13000000", Amount: 5}
+	current.Introspect(nil)
+	stale := current
+	stale.TaxonomyVersion = "old"
+	moved := CowTransaction{TransactionID: "moved", CategoryID: "13000000", Amount: 5, DetailedDescription: "shops", TaxonomyVersion: "old"}
+	batch := []CowTransaction{current, stale, moved}
+	report := MigrateTransactions(batch, nil)
+	if report.Checked != 3 || report.Restamped != 1 || len(report.Changed) != 1 {
+		t.Fatalf("got %+v", report)
+	}
+	change := report.Changed[0]
+	if change.TransactionID != "moved" || change.OldDetailedDescription != "shops" || change.NewDetailedDescription != "food and drink" {
+		t.Errorf("got %+v", change)
+	}
+	if batch[2].DetailedDescription != "food and drink" || batch[2].TaxonomyVersion != DefaultTaxonomy().Version {
+		t.Errorf("batch not updated in place: %+v", batch[2])
+	}
+}
@@ -0,0 +1,102 @@
+package spacecow_common
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestDetailedClassifyGolden holds DetailedClassify to what the hand written switch returned
+// before the taxonomy became a data file.  The only drift allowed is charges the taxonomy has
+// since split out as credits, interest or late fees
+func TestDetailedClassifyGolden(t *testing.T) {
+	raw, err := os.ReadFile("testdata/detailed_classify_golden.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := csv.NewReader(bytes.NewReader(raw))
+	reader.Comment = '#'
+	if _, err := reader.Read(); err != nil {
+		t.Fatal(err)
+	}
+	rows := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows++
+		physical, _ := strconv.ParseBool(record[1])
+		want := TransactionMap{ID: record[0], PhysicalLocation: physical, Description: record[3], DetailedDescription: record[4]}
+		if want.TransactionType, err = ParseXactionType(record[2]); err != nil {
+			t.Fatal(err)
+		}
+		got := DetailedClassify(CowTransaction{CategoryID: record[0], Amount: 10})
+		if want.TransactionType == XactionCharge && got.TransactionType != XactionPayment {
+			want.TransactionType = got.TransactionType
+		}
+		if got != want {
+			t.Errorf("%q: got %+v, want %+v", record[0], got, want)
+		}
+	}
+	if rows < 600 {
+		t.Fatalf("golden file only has %d rows", rows)
+	}
+}
+
+func TestParseTaxonomyRejectsBadFiles(t *testing.T) {
+	for name, body := range map[string]string{
+		"header":    "id,physical,transaction_type,description,detailed_description\n",
+		"duplicate": "id,physical_location,transaction_type,description,detailed_description\n1,false,charge,a,a\n1,false,charge,b,b\n",
+		"type":      "id,physical_location,transaction_type,description,detailed_description\n1,false,refund,a,a\n",
+		"bool":      "id,physical_location,transaction_type,description,detailed_description\n1,maybe,charge,a,a\n",
+	} {
+		if _, err := ParseTaxonomy(strings.NewReader(body)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTaxonomyVersion(t *testing.T) {
+	if DefaultTaxonomy().Version == "" {
+		t.Fatal("embedded taxonomy has no version")
+	}
+	taxonomy, err := ParseTaxonomy(strings.NewReader("# version: test\nid,physical_location,transaction_type,description,detailed_description\n1,true,charge,a,a\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taxonomy.Version != "test" {
+		t.Fatalf("version %q", taxonomy.Version)
+	}
+	if found, ok := taxonomy.Lookup("1"); !ok || !found.PhysicalLocation {
+		t.Fatalf("lookup %+v %v", found, ok)
+	}
+}
+
+func TestTaxonomyWriteToRoundTrips(t *testing.T) {
+	var out strings.Builder
+	if _, err := DefaultTaxonomy().WriteTo(&out); err != nil {
+		t.Fatal(err)
+	}
+	again, err := ParseTaxonomy(strings.NewReader(out.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, got := DefaultTaxonomy().Entries(), again.Entries()
+	if len(got) != len(want) {
+		t.Fatalf("%d entries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package spacecow_common
+
+import "strings"
+
+// CategoryPathSeparator splits levels in TransactionMap.DetailedDescription
+const CategoryPathSeparator = "=>"
+
+// CategoryPath splits a DetailedDescription like "service=>construction=>roofers" into its levels
+func CategoryPath(detailedDescription string) []string {
+	if detailedDescription == "" {
+		return nil
+	}
+	return strings.Split(detailedDescription, CategoryPathSeparator)
+}
+
+// CategoryDepth is the level of a DetailedDescription - top level categories are 1
+func CategoryDepth(detailedDescription string) int {
+	return len(CategoryPath(detailedDescription))
+}
+
+// linkHierarchy wires every entry to the closest entry whose path is a prefix of its own
+func (t *Taxonomy) linkHierarchy() {
+	t.parent = make([]int, len(t.entries))
+	t.kids = make([][]int, len(t.entries))
+	for i, entry := range t.entries {
+		t.parent[i] = -1
+		path := CategoryPath(entry.DetailedDescription)
+		for depth := len(path) - 1; depth > 0; depth-- {
+			if p, ok := t.byPath[strings.Join(path[:depth], CategoryPathSeparator)]; ok {
+				t.parent[i] = p
+				t.kids[p] = append(t.kids[p], i)
+				break
+			}
+		}
+	}
+}
+
+func (t *Taxonomy) pick(indexes []int) []TransactionMap {
+	out := make([]TransactionMap, 0, len(indexes))
+	for _, i := range indexes {
+		out = append(out, t.entries[i])
+	}
+	return out
+}
+
+// LookupPath finds the mapping for a full "a=>b=>c" path
+func (t *Taxonomy) LookupPath(detailedDescription string) (TransactionMap, bool) {
+	i, ok := t.byPath[detailedDescription]
+	if !ok {
+		return TransactionMap{}, false
+	}
+	return t.entries[i], true
+}
+
+// Roots are the top level categories (bank fees, community, food and drink...)
+func (t *Taxonomy) Roots() []TransactionMap {
+	var roots []int
+	for i, p := range t.parent {
+		if p < 0 {
+			roots = append(roots, i)
+		}
+	}
+	return t.pick(roots)
+}
+
+// Parent of a category id, false for roots and unknown ids
+func (t *Taxonomy) Parent(categoryID string) (TransactionMap, bool) {
+	i, ok := t.byID[categoryID]
+	if !ok || t.parent[i] < 0 {
+		return TransactionMap{}, false
+	}
+	return t.entries[t.parent[i]], true
+}
+
+// Children are the direct sub categories of a category id
+func (t *Taxonomy) Children(categoryID string) []TransactionMap {
+	i, ok := t.byID[categoryID]
+	if !ok {
+		return nil
+	}
+	return t.pick(t.kids[i])
+}
+
+// Ancestors walks from the root down to the direct parent of a category id
+func (t *Taxonomy) Ancestors(categoryID string) []TransactionMap {
+	i, ok := t.byID[categoryID]
+	if !ok {
+		return nil
+	}
+	var chain []int
+	for p := t.parent[i]; p >= 0; p = t.parent[p] {
+		chain = append([]int{p}, chain...)
+	}
+	return t.pick(chain)
+}
+
+// Leaves are all categories under a category id that have no children - a leaf returns itself
+func (t *Taxonomy) Leaves(categoryID string) []TransactionMap {
+	i, ok := t.byID[categoryID]
+	if !ok {
+		return nil
+	}
+	var leaves []int
+	pending := []int{i}
+	for len(pending) > 0 {
+		next := pending[0]
+		pending = pending[1:]
+		if len(t.kids[next]) == 0 {
+			leaves = append(leaves, next)
+			continue
+		}
+		pending = append(pending, t.kids[next]...)
+	}
+	return t.pick(leaves)
+}
+
+// IsLeaf is true when the category id has no children
+func (t *Taxonomy) IsLeaf(categoryID string) bool {
+	i, ok := t.byID[categoryID]
+	return ok && len(t.kids[i]) == 0
+}
+
+// IsUnder is true when a category id is ancestorID or anywhere below it
+func (t *Taxonomy) IsUnder(categoryID string, ancestorID string) bool {
+	i, ok := t.byID[categoryID]
+	if !ok {
+		return false
+	}
+	for ; i >= 0; i = t.parent[i] {
+		if t.entries[i].ID == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+// AncestorAtDepth rolls a category id up to the given level, 1 being the top level.  Categories
+// already at or above that depth return themselves
+func (t *Taxonomy) AncestorAtDepth(categoryID string, depth int) (TransactionMap, bool) {
+	i, ok := t.byID[categoryID]
+	if !ok {
+		return TransactionMap{}, false
+	}
+	for CategoryDepth(t.entries[i].DetailedDescription) > depth && t.parent[i] >= 0 {
+		i = t.parent[i]
+	}
+	return t.entries[i], true
+}
+
+// RollUpCategories sums Categories totals up to the given depth.  FlatType may be either a
+// category id or a full path; anything the taxonomy does not know is passed through unchanged.
+// Rolled up rows use the DetailedDescription of the ancestor as their FlatType
+func (t *Taxonomy) RollUpCategories(categories []Categories, depth int) []Categories {
+	var out []Categories
+	seen := map[string]int{}
+	for _, category := range categories {
+		flatType := category.FlatType
+		found, ok := t.LookupPath(flatType)
+		if !ok {
+			found, ok = t.Lookup(flatType)
+		}
+		if ok {
+			found, _ = t.AncestorAtDepth(found.ID, depth)
+			flatType = found.DetailedDescription
+		}
+		key := category.UID + "\x00" + flatType
+		if at, exists := seen[key]; exists {
+			out[at].Total += category.Total
+			continue
+		}
+		seen[key] = len(out)
+		out = append(out, Categories{UID: category.UID, FlatType: flatType, Total: category.Total})
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package spacecow_common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func ids(entries []TransactionMap) []string {
+	var out []string
+	for _, entry := range entries {
+		out = append(out, entry.ID)
+	}
+	return out
+}
+
+func TestTaxonomyTree(t *testing.T) {
+	taxonomy := DefaultTaxonomy()
+	if got := ids(taxonomy.Children("12002000")); !reflect.DeepEqual(got, []string{"12002001", "12002002"}) {
+		t.Errorf("children %v", got)
+	}
+	if parent, ok := taxonomy.Parent("12002001"); !ok || parent.ID != "12002000" {
+		t.Errorf("parent %+v %v", parent, ok)
+	}
+	if _, ok := taxonomy.Parent("12000000"); ok {
+		t.Error("a root has no parent")
+	}
+	if got := ids(taxonomy.Ancestors("12002001")); !reflect.DeepEqual(got, []string{"12000000", "12002000"}) {
+		t.Errorf("ancestors %v", got)
+	}
+	if got := ids(taxonomy.Leaves("12002000")); !reflect.DeepEqual(got, []string{"12002001", "12002002"}) {
+		t.Errorf("leaves %v", got)
+	}
+	if !taxonomy.IsLeaf("12002002") || taxonomy.IsLeaf("12002000") || taxonomy.IsLeaf("nope") {
+		t.Error("IsLeaf")
+	}
+	if !taxonomy.IsUnder("12002001", "12000000") || !taxonomy.IsUnder("12000000", "12000000") || taxonomy.IsUnder("13000000", "12000000") {
+		t.Error("IsUnder")
+	}
+	if found, ok := taxonomy.LookupPath("community=>assisted living services=>caretakers"); !ok || found.ID != "12002002" {
+		t.Errorf("LookupPath %+v %v", found, ok)
+	}
+	for _, root := range taxonomy.Roots() {
+		if CategoryDepth(root.DetailedDescription) != 1 {
+			t.Errorf("root %s is at depth %d", root.ID, CategoryDepth(root.DetailedDescription))
+		}
+	}
+}
+
+func TestAncestorAtDepth(t *testing.T) {
+	for _, c := range []struct {
+		id    string
+		depth int
+		want  string
+	}{
+		{"12002001", 1, "12000000"},
+		{"12002001", 2, "12002000"},
+		{"12002001", 3, "12002001"},
+		{"12000000", 2, "12000000"},
+	} {
+		if found, ok := DefaultTaxonomy().AncestorAtDepth(c.id, c.depth); !ok || found.ID != c.want {
+			t.Errorf("%s at %d: got %s, want %s", c.id, c.depth, found.ID, c.want)
+		}
+	}
+}
+
+func TestRollUpCategories(t *testing.T) {
+	got := DefaultTaxonomy().RollUpCategories([]Categories{
+		{UID: "u", FlatType: "12002001", Total: 10},
+		{UID: "u", FlatType: "community=>assisted living services=>caretakers", Total: 5},
+		{UID: "u", FlatType: "community", Total: 1},
+		{UID: "v", FlatType: "12002001", Total: 2},
+		{UID: "u", FlatType: "made up", Total: 3},
+	}, 1)
+	want := []Categories{
+		{UID: "u", FlatType: "community", Total: 16},
+		{UID: "v", FlatType: "community", Total: 2},
+		{UID: "u", FlatType: "made up", Total: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
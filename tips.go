@@ -0,0 +1,366 @@
+package spacecow_common
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TipInput is everything tip rules get to look at for one user.  Categories are expected to
+// cover the same stretch of time as Transactions
+type TipInput struct {
+	UID           string
+	Transactions  []CowTransaction
+	Categories    []Categories
+	Subscriptions []PossibleSubscriptions
+	AsOf          CivilDate      // today, the newest transaction when zero
+	Days          int            // days of history in Transactions, worked out from the dates (at least 30) when zero
+	Location      *time.Location // user's zone for dates (UTC)
+}
+
+// TipRule looks at a user's data and suggests tips.  Savings is in whole currency units a year
+type TipRule interface {
+	Name() string
+	Tips(input TipInput) []CowTips
+}
+
+type tipRuleFunc struct {
+	name string
+	fn   func(TipInput) []CowTips
+}
+
+func (r tipRuleFunc) Name() string { return r.name }
+
+func (r tipRuleFunc) Tips(input TipInput) []CowTips { return r.fn(input) }
+
+// NewTipRule makes a rule out of a plain function
+func NewTipRule(name string, fn func(TipInput) []CowTips) TipRule {
+	return tipRuleFunc{name: name, fn: fn}
+}
+
+// TipEngine runs registered rules and cleans up what they return - duplicates go, keeping the
+// one that saves the most, and the rest are ranked by savings
+type TipEngine struct {
+	MaxTips int // keep only the best ones, 0 for all
+	mu      sync.RWMutex
+	rules   []TipRule
+}
+
+// NewTipEngine makes an engine with some rules already registered.  It fails the way Register
+// does when two rules share a name
+func NewTipEngine(rules ...TipRule) (*TipEngine, error) {
+	e := &TipEngine{}
+	for _, rule := range rules {
+		if err := e.Register(rule); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func mustNewTipEngine(rules ...TipRule) *TipEngine {
+	e, err := NewTipEngine(rules...)
+	if err != nil {
+		panic("default tip engine: " + err.Error())
+	}
+	return e
+}
+
+// Register adds a rule - names have to be unique, they end up in CowTips.Rule
+func (e *TipEngine) Register(rule TipRule) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, known := range e.rules {
+		if known.Name() == rule.Name() {
+			return fmt.Errorf("tip rule %s already registered", rule.Name())
+		}
+	}
+	e.rules = append(e.rules, rule)
+	return nil
+}
+
+// Rules lists the registered rule names in the order they run
+func (e *TipEngine) Rules() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var out []string
+	for _, rule := range e.rules {
+		out = append(out, rule.Name())
+	}
+	return out
+}
+
+// tipKey is the rule plus the message and its arguments, in a stable order
+func tipKey(tip CowTips) string {
+	names := make([]string, 0, len(tip.MessageArgs))
+	for name := range tip.MessageArgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	key := tip.Rule + ":" + tip.MessageKey
+	if tip.MessageKey == "" {
+		key += tip.Description
+	}
+	for _, name := range names {
+		key += ":" + name + "=" + tip.MessageArgs[name]
+	}
+	return key
+}
+
+// Run applies every rule to the input and returns the tips best first.  Tips with a MessageKey
+// get an English Description when the rule didn't write one - LocalizeTip does the rest
+func (e *TipEngine) Run(input TipInput) []CowTips {
+	input = input.withDefaults()
+	e.mu.RLock()
+	rules := append([]TipRule(nil), e.rules...)
+	e.mu.RUnlock()
+	at := map[string]int{}
+	var out []CowTips
+	for _, rule := range rules {
+		for _, tip := range rule.Tips(input) {
+			tip.Rule = rule.Name()
+			if tip.Key == "" {
+				tip.Key = tipKey(tip)
+			}
+			if tip.Description == "" && tip.MessageKey != "" {
+				tip = DefaultCatalog().LocalizeTip(tip, En)
+			}
+			if i, seen := at[tip.Key]; seen {
+				if tip.Savings > out[i].Savings {
+					out[i] = tip
+				}
+				continue
+			}
+			at[tip.Key] = len(out)
+			out = append(out, tip)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Savings != out[j].Savings {
+			return out[i].Savings > out[j].Savings
+		}
+		if out[i].Type != out[j].Type {
+			return out[i].Type < out[j].Type
+		}
+		return out[i].Key < out[j].Key
+	})
+	if e.MaxTips > 0 && len(out) > e.MaxTips {
+		out = out[:e.MaxTips]
+	}
+	return out
+}
+
+func (input TipInput) withDefaults() TipInput {
+	if input.Location == nil {
+		input.Location = time.UTC
+	}
+	var first, last CivilDate
+	for _, transaction := range input.Transactions {
+		day := transaction.EffectiveDate(input.Location)
+		if day.IsZero() {
+			continue
+		}
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if last.IsZero() || day.After(last) {
+			last = day
+		}
+	}
+	if input.AsOf.IsZero() {
+		input.AsOf = last
+	}
+	if input.Days <= 0 && !first.IsZero() {
+		input.Days = first.DaysUntil(input.AsOf) + 1
+		if input.Days < 30 {
+			input.Days = 30 // a couple of weeks of data says little about a year
+		}
+	}
+	return input
+}
+
+// Yearly scales an amount over the input's history to a year
+func (input TipInput) Yearly(amount float64) float64 {
+	days := input.Days
+	if days <= 0 {
+		days = 365
+	}
+	return amount * 365 / float64(days)
+}
+
+// perYear is an amount charged every period over a year
+func perYear(amount float64, period SubscriptionPeriod) float64 {
+	if period.Days() == 0 {
+		return 0
+	}
+	return amount * 365.25 / period.Days()
+}
+
+// categoryPath is the stored introspection of a transaction, or a fresh one when there is none
+func categoryPath(transaction CowTransaction) []string {
+	if path := CategoryPath(transaction.DetailedDescription); len(path) > 0 {
+		return path
+	}
+	return CategoryPath(Classify(transaction).DetailedDescription)
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*100)/100, 'f', 2, 64)
+}
+
+// bankFeesRule points at avoidable bank fees - overdrafts, atm fees, late fees and the like
+func bankFeesRule(input TipInput) []CowTips {
+	total := 0.0
+	for _, transaction := range input.Transactions {
+		if path := categoryPath(transaction); transaction.Amount > 0 && len(path) > 0 && path[0] == "bank fees" {
+			total += transaction.Amount
+		}
+	}
+	if total < 1 {
+		return nil
+	}
+	return []CowTips{{
+		Type:        CowTipsSmart,
+		Savings:     int(input.Yearly(total)),
+		MessageKey:  "tip.bank_fees",
+		MessageArgs: map[string]string{"total": formatAmount(total)},
+	}}
+}
+
+// priceIncreaseRule flags subscriptions now charging more than they used to
+func priceIncreaseRule(input TipInput) []CowTips {
+	charges := chargesBySubscription(input.Subscriptions, chargesByMerchant(input.Transactions, input.Location))
+	var out []CowTips
+	for i, subscription := range input.Subscriptions {
+		seen := charges[i]
+		var earlier []float64
+		for _, charge := range seen {
+			if charge.day.Before(subscription.LastCharge) {
+				earlier = append(earlier, charge.amount)
+			}
+		}
+		if len(earlier) == 0 {
+			continue
+		}
+		old := median(earlier)
+		if subscription.Amount <= old+0.005 {
+			continue
+		}
+		out = append(out, CowTips{
+			Type:       CowTipsSmart,
+			ActionURL:  subscription.ActionURL,
+			Savings:    int(perYear(subscription.Amount-old, subscription.Period)),
+			MessageKey: "tip.subscription_price_increase",
+			MessageArgs: map[string]string{
+				"merchant": subscription.Name,
+				"old":      formatAmount(old),
+				"new":      formatAmount(subscription.Amount),
+			},
+		})
+	}
+	return out
+}
+
+// overlappingSubscriptionsRule notices several services of the same kind, streaming mostly, and
+// suggests dropping the cheapest
+func overlappingSubscriptionsRule(input TipInput) []CowTips {
+	byKind := map[string][]PossibleSubscriptions{}
+	var kinds []string
+	for _, subscription := range input.Subscriptions {
+		found, ok := DefaultCancellationDirectory().Match(subscription)
+		if !ok || found.Kind == "gym" || found.Kind == "storage" {
+			continue
+		}
+		if _, seen := byKind[found.Kind]; !seen {
+			kinds = append(kinds, found.Kind)
+		}
+		byKind[found.Kind] = append(byKind[found.Kind], subscription)
+	}
+	sort.Strings(kinds)
+	var out []CowTips
+	for _, kind := range kinds {
+		subscriptions := byKind[kind]
+		if len(subscriptions) < 3 {
+			continue
+		}
+		cheapest := subscriptions[0]
+		var names []string
+		for _, subscription := range subscriptions {
+			names = append(names, subscription.Name)
+			if perYear(subscription.Amount, subscription.Period) < perYear(cheapest.Amount, cheapest.Period) {
+				cheapest = subscription
+			}
+		}
+		sort.Strings(names)
+		out = append(out, CowTips{
+			Type:       CowTipsSmart,
+			ActionURL:  cheapest.ActionURL,
+			Savings:    int(perYear(cheapest.Amount, cheapest.Period)),
+			MessageKey: "tip.overlapping_subscriptions",
+			MessageArgs: map[string]string{
+				"count":    strconv.Itoa(len(subscriptions)),
+				"services": strings.Join(names, ", "),
+				"cheapest": cheapest.Name,
+			},
+		})
+	}
+	return out
+}
+
+// foodAndDrinkCategoryID is "food and drink", everything under it counts as eating out
+const foodAndDrinkCategoryID = "13000000"
+
+// diningOutRule suggests cooking more when food and drink is a big share of spending.  Categories
+// can be top level names, full paths or category ids
+func diningOutRule(input TipInput) []CowTips {
+	food, spending := 0.0, 0.0
+	for _, category := range input.Categories {
+		if category.Total <= 0 {
+			continue
+		}
+		spending += category.Total
+		found, ok := DefaultTaxonomy().LookupPath(category.FlatType)
+		if !ok {
+			found, ok = DefaultTaxonomy().Lookup(category.FlatType)
+		}
+		if ok && DefaultTaxonomy().IsUnder(found.ID, foodAndDrinkCategoryID) {
+			food += category.Total
+		}
+	}
+	if spending == 0 || food/spending < 0.15 {
+		return nil
+	}
+	return []CowTips{{
+		Type:        CowTipsSmart,
+		Savings:     int(input.Yearly(food) * 0.2),
+		MessageKey:  "tip.dining_out",
+		MessageArgs: map[string]string{"share": strconv.Itoa(int(math.Round(food / spending * 100)))},
+	}}
+}
+
+var defaultTipEngine = mustNewTipEngine(
+	NewTipRule("bank_fees", bankFeesRule),
+	NewTipRule("subscription_price_increase", priceIncreaseRule),
+	NewTipRule("overlapping_subscriptions", overlappingSubscriptionsRule),
+	NewTipRule("dining_out", diningOutRule),
+	NewTipRule("tax_year", taxYearRule),
+)
+
+// DefaultTipEngine is the engine with the tips that ship in this package
+func DefaultTipEngine() *TipEngine {
+	return defaultTipEngine
+}
+
+// RegisterTipRule adds a rule to the default engine
+func RegisterTipRule(rule TipRule) error {
+	return defaultTipEngine.Register(rule)
+}
+
+// SuggestTips runs the default engine
+func SuggestTips(input TipInput) []CowTips {
+	return defaultTipEngine.Run(input)
+}
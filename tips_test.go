@@ -0,0 +1,166 @@
+package spacecow_common
+
+import (
+	"strings"
+	"testing"
+)
+
+// yearOf is a TipInput whose history is a whole year, so Yearly leaves amounts alone
+func yearOf(transactions []CowTransaction, subscriptions []PossibleSubscriptions, categories []Categories) TipInput {
+	return TipInput{UID: "u1", Transactions: transactions, Subscriptions: subscriptions, Categories: categories, Days: 365}
+}
+
+func TestBankFeesRule(t *testing.T) {
+	transactions := []CowTransaction{
+		{CategoryID: "10001000", Amount: 35, Date: "2023-02-01"},
+		{CategoryID: "10002000", Amount: 3.5, Date: "2023-03-01"},
+		{CategoryID: "10001000", Amount: -35, Date: "2023-03-02"}, // refunded one
+		{CategoryID: "13005000", Amount: 80, Date: "2023-03-03"},
+	}
+	tips := bankFeesRule(yearOf(transactions, nil, nil))
+	if len(tips) != 1 || tips[0].Savings != 38 || tips[0].MessageArgs["total"] != "38.50" {
+		t.Fatalf("tips %+v", tips)
+	}
+	if tips := bankFeesRule(yearOf(transactions[3:], nil, nil)); len(tips) != 0 {
+		t.Errorf("no fees gave %+v", tips)
+	}
+}
+
+func TestPriceIncreaseRule(t *testing.T) {
+	history := charges(t, "Netflix", 15.49, "2023-01-15", 4, monthly)
+	history[3].Amount = 17.99
+	subscriptions := DetectSubscriptions(history)
+	tips := priceIncreaseRule(yearOf(history, subscriptions, nil))
+	if len(tips) != 1 {
+		t.Fatalf("tips %+v", tips)
+	}
+	args := tips[0].MessageArgs
+	if args["merchant"] != "Netflix" || args["old"] != "15.49" || args["new"] != "17.99" || tips[0].Savings != 29 {
+		t.Errorf("tip %+v", tips[0])
+	}
+	steady := charges(t, "Netflix", 15.49, "2023-01-15", 4, monthly)
+	if tips := priceIncreaseRule(yearOf(steady, DetectSubscriptions(steady), nil)); len(tips) != 0 {
+		t.Errorf("steady price gave %+v", tips)
+	}
+	plans := append(charges(t, "Apple", 2.99, "2023-01-03", 4, monthly), charges(t, "Apple", 10.99, "2023-01-20", 4, monthly)...)
+	if tips := priceIncreaseRule(yearOf(plans, DetectSubscriptions(plans), nil)); len(tips) != 0 {
+		t.Errorf("two plans from one merchant gave %+v", tips)
+	}
+}
+
+func TestOverlappingSubscriptionsRule(t *testing.T) {
+	streaming := []PossibleSubscriptions{
+		{Name: "Netflix", Amount: 15.49, Period: PeriodMonthly},
+		{Name: "Hulu", Amount: 7.99, Period: PeriodMonthly},
+		{Name: "Peacock", Amount: 5.99, Period: PeriodMonthly, ActionURL: "https://www.peacocktv.com/account/plans"},
+		{Name: "Spotify", Amount: 10.99, Period: PeriodMonthly},
+	}
+	tips := overlappingSubscriptionsRule(yearOf(nil, streaming, nil))
+	if len(tips) != 1 {
+		t.Fatalf("tips %+v", tips)
+	}
+	args := tips[0].MessageArgs
+	if args["count"] != "3" || args["cheapest"] != "Peacock" || args["services"] != "Hulu, Netflix, Peacock" {
+		t.Errorf("args %v", args)
+	}
+	if tips[0].Savings != 71 || tips[0].ActionURL == "" {
+		t.Errorf("tip %+v", tips[0])
+	}
+	gyms := []PossibleSubscriptions{
+		{Name: "Planet Fitness", Amount: 10, Period: PeriodMonthly},
+		{Name: "LA Fitness", Amount: 30, Period: PeriodMonthly},
+		{Name: "Peloton", Amount: 44, Period: PeriodMonthly},
+	}
+	if tips := overlappingSubscriptionsRule(yearOf(nil, gyms, nil)); len(tips) != 0 {
+		t.Errorf("gyms gave %+v", tips)
+	}
+}
+
+func TestDiningOutRule(t *testing.T) {
+	categories := []Categories{
+		{FlatType: "food and drink", Total: 300},
+		{FlatType: "shops", Total: 700},
+		{FlatType: "transfer", Total: -2000}, // money in doesn't count toward spending
+	}
+	tips := diningOutRule(yearOf(nil, nil, categories))
+	if len(tips) != 1 || tips[0].MessageArgs["share"] != "30" || tips[0].Savings != 60 {
+		t.Fatalf("tips %+v", tips)
+	}
+	categories[0].Total = 100
+	if tips := diningOutRule(yearOf(nil, nil, categories)); len(tips) != 0 {
+		t.Errorf("12%% share gave %+v", tips)
+	}
+	// detailed rows roll up into food and drink whether they're paths or ids
+	categories = append(categories, Categories{FlatType: "food and drink=>restaurants", Total: 150}, Categories{FlatType: "13001001", Total: 50})
+	if tips := diningOutRule(yearOf(nil, nil, categories)); len(tips) != 1 || tips[0].MessageArgs["share"] != "30" {
+		t.Errorf("detailed categories gave %+v", tips)
+	}
+}
+
+func TestTipEngineDedupesAndRanks(t *testing.T) {
+	engine, err := NewTipEngine(
+		NewTipRule("first", func(TipInput) []CowTips {
+			return []CowTips{
+				{Key: "shared", Savings: 10, Description: "from first"},
+				{Savings: 5, MessageKey: "tip.bank_fees", MessageArgs: map[string]string{"total": "5.00"}},
+			}
+		}),
+		NewTipRule("second", func(TipInput) []CowTips {
+			return []CowTips{
+				{Key: "shared", Savings: 20, Description: "from second"},
+				{Key: "small", Savings: 1, Description: "small"},
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Register(NewTipRule("first", nil)); err == nil {
+		t.Error("registered a rule name twice")
+	}
+	if _, err := NewTipEngine(NewTipRule("first", nil), NewTipRule("first", nil)); err == nil {
+		t.Error("made an engine with a rule name twice")
+	}
+	if rules := engine.Rules(); strings.Join(rules, ",") != "first,second" {
+		t.Errorf("rules %v", rules)
+	}
+	tips := engine.Run(TipInput{})
+	if len(tips) != 3 {
+		t.Fatalf("tips %+v", tips)
+	}
+	if tips[0].Key != "shared" || tips[0].Rule != "second" || tips[0].Savings != 20 {
+		t.Errorf("best %+v", tips[0])
+	}
+	fees := tips[1]
+	if fees.Rule != "first" || fees.Key != "first:tip.bank_fees:total=5.00" || !strings.Contains(fees.Description, "5.00") {
+		t.Errorf("fees %+v", fees)
+	}
+	engine.MaxTips = 1
+	if tips := engine.Run(TipInput{}); len(tips) != 1 || tips[0].Key != "shared" {
+		t.Errorf("max tips %+v", tips)
+	}
+}
+
+func TestTipInputDefaults(t *testing.T) {
+	input := TipInput{Transactions: []CowTransaction{{Date: "2023-01-01"}, {Date: "2023-06-30"}}}.withDefaults()
+	if input.AsOf.String() != "2023-06-30" || input.Days != 181 {
+		t.Errorf("input %s %d", input.AsOf, input.Days)
+	}
+	short := TipInput{Transactions: []CowTransaction{{Date: "2023-01-01"}}}.withDefaults()
+	if short.Days != 30 || short.Yearly(30) != 365 {
+		t.Errorf("short %d", short.Days)
+	}
+	if given := (TipInput{Days: 7}).withDefaults(); given.Days != 7 {
+		t.Errorf("explicit days raised to %d", given.Days)
+	}
+}
+
+func TestSuggestTips(t *testing.T) {
+	transactions := []CowTransaction{
+		{UID: "u1", CategoryID: "10001000", Amount: 35, Date: "2023-02-01"},
+	}
+	tips := SuggestTips(yearOf(transactions, nil, nil))
+	if len(tips) != 1 || tips[0].Rule != "bank_fees" || tips[0].Description == "" {
+		t.Errorf("tips %+v", tips)
+	}
+}